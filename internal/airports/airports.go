@@ -0,0 +1,146 @@
+package airports
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	// Bundle the time zone database so local times work on any system
+	_ "time/tzdata"
+)
+
+//go:embed airports.csv
+var airportsCSV string
+
+// Airport is a bundled airport record
+type Airport struct {
+	IATA     string  `json:"iata"`
+	Name     string  `json:"name"`
+	City     string  `json:"city"`
+	Country  string  `json:"country"` // ISO 3166-1 alpha-2
+	Lat      float64 `json:"lat"`
+	Lon      float64 `json:"lon"`
+	TimeZone string  `json:"tz"`
+}
+
+var (
+	loadOnce  sync.Once
+	byCode    map[string]Airport
+	locations sync.Map // tz name -> *time.Location
+)
+
+func load() {
+	byCode = map[string]Airport{}
+
+	records, err := csv.NewReader(strings.NewReader(airportsCSV)).ReadAll()
+	if err != nil {
+		panic("airports: invalid bundled data: " + err.Error())
+	}
+	for _, r := range records[1:] {
+		lat, _ := strconv.ParseFloat(r[4], 64)
+		lon, _ := strconv.ParseFloat(r[5], 64)
+		byCode[r[0]] = Airport{IATA: r[0], Name: r[1], City: r[2], Country: r[3], Lat: lat, Lon: lon, TimeZone: r[6]}
+	}
+}
+
+// Lookup returns the airport for an IATA code
+func Lookup(code string) (Airport, bool) {
+	loadOnce.Do(load)
+	a, ok := byCode[strings.ToUpper(code)]
+	return a, ok
+}
+
+// All returns every bundled airport, sorted by IATA code
+func All() []Airport {
+	loadOnce.Do(load)
+	out := make([]Airport, 0, len(byCode))
+	for _, a := range byCode {
+		out = append(out, a)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].IATA < out[j].IATA })
+	return out
+}
+
+// Location returns the time zone of an airport, or UTC for airports without
+// bundled data. Use HasTimeZone to tell the fallback apart.
+func Location(code string) *time.Location {
+	loc, _ := location(code)
+	return loc
+}
+
+// HasTimeZone reports whether an airport's local time is known
+func HasTimeZone(code string) bool {
+	_, ok := location(code)
+	return ok
+}
+
+func location(code string) (*time.Location, bool) {
+	a, ok := Lookup(code)
+	if !ok || a.TimeZone == "" {
+		return time.UTC, false
+	}
+	if loc, ok := locations.Load(a.TimeZone); ok {
+		return loc.(*time.Location), true
+	}
+	loc, err := time.LoadLocation(a.TimeZone)
+	if err != nil {
+		return time.UTC, false
+	}
+	locations.Store(a.TimeZone, loc)
+	return loc, true
+}
+
+// Local converts t to the local time at an airport. Trip and segment times
+// from the API are assumed to be UTC instants, so converting them gives the
+// local wall clock; airports without a bundled time zone stay in UTC.
+func Local(code string, t time.Time) time.Time {
+	return t.In(Location(code))
+}
+
+const earthRadiusMiles = 3958.8
+
+// Distance returns the great-circle distance between two airports in miles
+func Distance(from, to Airport) float64 {
+	lat1, lon1 := radians(from.Lat), radians(from.Lon)
+	lat2, lon2 := radians(to.Lat), radians(to.Lon)
+	h := math.Pow(math.Sin((lat2-lat1)/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin((lon2-lon1)/2), 2)
+	return 2 * earthRadiusMiles * math.Asin(math.Sqrt(h))
+}
+
+// GreatCircle returns points along the shortest path between two airports as
+// [lon, lat] pairs, including both ends. Longitudes stay within [-180, 180],
+// so paths over the antimeridian jump from one edge to the other.
+func GreatCircle(from, to Airport, points int) [][2]float64 {
+	if points < 2 {
+		points = 2
+	}
+
+	lat1, lon1 := radians(from.Lat), radians(from.Lon)
+	lat2, lon2 := radians(to.Lat), radians(to.Lon)
+	d := Distance(from, to) / earthRadiusMiles
+
+	out := make([][2]float64, points)
+	for i := range out {
+		f := float64(i) / float64(points-1)
+		if d == 0 {
+			out[i] = [2]float64{from.Lon, from.Lat}
+			continue
+		}
+		a := math.Sin((1-f)*d) / math.Sin(d)
+		b := math.Sin(f*d) / math.Sin(d)
+		x := a*math.Cos(lat1)*math.Cos(lon1) + b*math.Cos(lat2)*math.Cos(lon2)
+		y := a*math.Cos(lat1)*math.Sin(lon1) + b*math.Cos(lat2)*math.Sin(lon2)
+		z := a*math.Sin(lat1) + b*math.Sin(lat2)
+		out[i] = [2]float64{degrees(math.Atan2(y, x)), degrees(math.Atan2(z, math.Sqrt(x*x+y*y)))}
+	}
+	return out
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
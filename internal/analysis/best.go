@@ -0,0 +1,172 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// Option is a single bookable award for one cabin
+type Option struct {
+	ID       string `json:"id"`
+	Date     string `json:"date"`
+	Route    string `json:"route"`
+	Source   string `json:"source"`
+	Miles    int    `json:"miles"`
+	Seats    int    `json:"seats"`
+	Airlines string `json:"airlines,omitempty"`
+	Direct   bool   `json:"direct"`
+}
+
+// RouteBest is the cheapest program for a route in one cabin
+type RouteBest struct {
+	Route    string `json:"route"`
+	Options  int    `json:"options"`
+	Programs int    `json:"programs"`
+	Best     Option `json:"best"`
+}
+
+// CabinBest summarizes the best options found for one cabin
+type CabinBest struct {
+	Cabin          string      `json:"cabin"`
+	Options        int         `json:"options"`
+	LowestMiles    int         `json:"lowestMiles"`
+	LowestDates    []Option    `json:"lowestDates"`
+	BestDirect     *Option     `json:"bestDirect,omitempty"`
+	BestConnecting *Option     `json:"bestConnecting,omitempty"`
+	Routes         []RouteBest `json:"routes"`
+}
+
+// Best is a per-cabin summary of a search
+type Best struct {
+	Params  api.SearchParams `json:"params"`
+	Results int              `json:"results"`
+	Cabins  []CabinBest      `json:"cabins"`
+}
+
+// Summarize reports, for each cabin with availability, the lowest-miles
+// dates, the cheapest program per route and the best direct and connecting
+// alternatives. Cabins default to all of Y, W, J and F.
+func Summarize(params api.SearchParams, results []api.Availability, cabins []string) Best {
+	if len(cabins) == 0 {
+		cabins = api.ValidCabins()
+	}
+
+	best := Best{Params: params, Results: len(results)}
+	for _, cabin := range cabins {
+		if cb, ok := summarizeCabin(results, cabin); ok {
+			best.Cabins = append(best.Cabins, cb)
+		}
+	}
+	return best
+}
+
+func summarizeCabin(results []api.Availability, cabin string) (CabinBest, bool) {
+	cb := CabinBest{Cabin: cabin}
+	routes := map[string]*RouteBest{}
+	programs := map[string]map[string]bool{}
+
+	for _, a := range results {
+		c := a.Cabin(cabin)
+		if !c.Available || c.Miles == 0 {
+			continue
+		}
+		cb.Options++
+
+		opt := Option{
+			ID:       a.ID,
+			Date:     a.Date,
+			Route:    a.Route.OriginAirport + "-" + a.Route.DestinationAirport,
+			Source:   a.Source,
+			Miles:    c.Miles,
+			Seats:    c.RemainingSeats,
+			Airlines: c.Airlines,
+			Direct:   c.Direct && (c.DirectMiles == 0 || c.DirectMiles <= c.Miles),
+		}
+
+		switch {
+		case cb.LowestMiles == 0 || opt.Miles < cb.LowestMiles:
+			cb.LowestMiles = opt.Miles
+			cb.LowestDates = []Option{opt}
+		case opt.Miles == cb.LowestMiles:
+			cb.LowestDates = append(cb.LowestDates, opt)
+		}
+
+		// The cheapest fare may connect even when a pricier direct exists
+		if direct, ok := directOption(opt, c); ok && better(direct, cb.BestDirect) {
+			cb.BestDirect = &direct
+		}
+		if !opt.Direct && better(opt, cb.BestConnecting) {
+			o := opt
+			cb.BestConnecting = &o
+		}
+
+		rb, ok := routes[opt.Route]
+		if !ok {
+			rb = &RouteBest{Route: opt.Route, Best: opt}
+			routes[opt.Route] = rb
+			programs[opt.Route] = map[string]bool{}
+		} else if better(opt, &rb.Best) {
+			rb.Best = opt
+		}
+		rb.Options++
+		programs[opt.Route][opt.Source] = true
+	}
+
+	if cb.Options == 0 {
+		return cb, false
+	}
+
+	sort.Slice(cb.LowestDates, func(i, j int) bool {
+		if cb.LowestDates[i].Date != cb.LowestDates[j].Date {
+			return cb.LowestDates[i].Date < cb.LowestDates[j].Date
+		}
+		return cb.LowestDates[i].Route < cb.LowestDates[j].Route
+	})
+
+	for route, rb := range routes {
+		rb.Programs = len(programs[route])
+		cb.Routes = append(cb.Routes, *rb)
+	}
+	sort.Slice(cb.Routes, func(i, j int) bool {
+		if cb.Routes[i].Best.Miles != cb.Routes[j].Best.Miles {
+			return cb.Routes[i].Best.Miles < cb.Routes[j].Best.Miles
+		}
+		return cb.Routes[i].Route < cb.Routes[j].Route
+	})
+
+	return cb, true
+}
+
+// directOption returns the direct alternative for an option, priced from the
+// direct fields when the API reports them
+func directOption(opt Option, c api.CabinAvailability) (Option, bool) {
+	if !c.Direct {
+		return Option{}, false
+	}
+	direct := opt
+	direct.Direct = true
+	if c.DirectMiles > 0 {
+		direct.Miles = c.DirectMiles
+		direct.Seats = c.DirectRemainingSeats
+		if c.DirectAirlines != "" {
+			direct.Airlines = c.DirectAirlines
+		}
+	}
+	return direct, true
+}
+
+// better reports whether o beats the current best: fewer miles, then more
+// seats, then the earlier date
+func better(o Option, current *Option) bool {
+	if current == nil {
+		return true
+	}
+	if o.Miles != current.Miles {
+		return o.Miles < current.Miles
+	}
+	if o.Seats != current.Seats {
+		return o.Seats > current.Seats
+	}
+	return o.Date < current.Date
+}
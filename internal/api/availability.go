@@ -54,23 +54,40 @@ func (c *Client) GetAvailability(params AvailabilityParams) (*AvailabilityRespon
 	return &response, nil
 }
 
-// GetAvailabilityAll retrieves all availability results, handling pagination
+// GetAvailabilityAll retrieves all results from availability, handling pagination
 func (c *Client) GetAvailabilityAll(params AvailabilityParams) ([]Availability, error) {
 	var allResults []Availability
+	err := c.GetAvailabilityEach(params, func(page []Availability) error {
+		allResults = append(allResults, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return allResults, nil
+}
 
+// GetAvailabilityEach pages through availability results, passing each page to fn as it
+// arrives so callers can stream without holding every result. An error from
+// fn stops paging and is returned.
+func (c *Client) GetAvailabilityEach(params AvailabilityParams, fn func(page []Availability) error) error {
 	params.Take = 100 // Max per page
 	params.Skip = 0
 
 	for {
 		resp, err := c.GetAvailability(params)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		allResults = append(allResults, resp.Data...)
+		if len(resp.Data) > 0 {
+			if err := fn(resp.Data); err != nil {
+				return err
+			}
+		}
 
 		if !resp.HasMore || len(resp.Data) == 0 {
-			break
+			return nil
 		}
 
 		params.Skip += len(resp.Data)
@@ -78,6 +95,4 @@ func (c *Client) GetAvailabilityAll(params AvailabilityParams) ([]Availability,
 			params.Cursor = resp.Cursor
 		}
 	}
-
-	return allResults, nil
 }
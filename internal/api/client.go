@@ -21,6 +21,8 @@ type Client struct {
 	httpClient *http.Client
 	apiKey     string
 	baseURL    string
+	limiter    *RateLimiter
+	quota      *Quota
 }
 
 // NewClient creates a new API client
@@ -46,6 +48,18 @@ func (c *Client) WithBaseURL(url string) *Client {
 	return c
 }
 
+// WithRateLimiter throttles requests through a shared rate limiter
+func (c *Client) WithRateLimiter(limiter *RateLimiter) *Client {
+	c.limiter = limiter
+	return c
+}
+
+// WithQuota counts requests against a daily quota, failing once it is used up
+func (c *Client) WithQuota(quota *Quota) *Client {
+	c.quota = quota
+	return c
+}
+
 // doRequest performs an authenticated HTTP request
 func (c *Client) doRequest(method, endpoint string, params map[string]string) ([]byte, error) {
 	url := fmt.Sprintf("%s%s", c.baseURL, endpoint)
@@ -70,6 +84,15 @@ func (c *Client) doRequest(method, endpoint string, params map[string]string) ([
 		req.URL.RawQuery = q.Encode()
 	}
 
+	if c.quota != nil {
+		if err := c.quota.Take(); err != nil {
+			return nil, err
+		}
+	}
+	if c.limiter != nil {
+		c.limiter.Wait()
+	}
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
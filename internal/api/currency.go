@@ -0,0 +1,34 @@
+package api
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// currencyDecimals lists ISO 4217 currencies whose minor unit isn't a
+// hundredth; every other currency has two decimals
+var currencyDecimals = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// CurrencyDecimals returns the number of decimals in a currency's minor unit
+func CurrencyDecimals(currency string) int {
+	if d, ok := currencyDecimals[strings.ToUpper(currency)]; ok {
+		return d
+	}
+	return 2
+}
+
+// TaxesAmount converts taxes in the currency's minor units, as the API
+// reports them, to whole units: 5600 is 56.00 USD but 5600 JPY
+func TaxesAmount(minor int, currency string) float64 {
+	return float64(minor) / math.Pow10(CurrencyDecimals(currency))
+}
+
+// FormatTaxes formats taxes in minor units with the currency's decimals
+func FormatTaxes(minor int, currency string) string {
+	return strconv.FormatFloat(TaxesAmount(minor, currency), 'f', CurrencyDecimals(currency), 64)
+}
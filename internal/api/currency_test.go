@@ -0,0 +1,26 @@
+package api
+
+import "testing"
+
+func TestTaxes(t *testing.T) {
+	tests := []struct {
+		minor    int
+		currency string
+		amount   float64
+		text     string
+	}{
+		{5600, "USD", 56, "56.00"},
+		{5600, "", 56, "56.00"},
+		{5600, "JPY", 5600, "5600"},
+		{5600, "krw", 5600, "5600"},
+		{5600, "KWD", 5.6, "5.600"},
+	}
+	for _, tt := range tests {
+		if got := TaxesAmount(tt.minor, tt.currency); got != tt.amount {
+			t.Errorf("TaxesAmount(%d, %q) = %v, want %v", tt.minor, tt.currency, got, tt.amount)
+		}
+		if got := FormatTaxes(tt.minor, tt.currency); got != tt.text {
+			t.Errorf("FormatTaxes(%d, %q) = %q, want %q", tt.minor, tt.currency, got, tt.text)
+		}
+	}
+}
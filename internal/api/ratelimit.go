@@ -0,0 +1,119 @@
+package api
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrQuotaExceeded is returned when the daily API call quota has been used up
+var ErrQuotaExceeded = errors.New("daily API quota exceeded")
+
+// RateLimiter spaces requests evenly to stay under a per-minute limit
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// NewRateLimiter creates a limiter allowing perMinute requests per minute
+func NewRateLimiter(perMinute int) *RateLimiter {
+	if perMinute <= 0 {
+		perMinute = 1
+	}
+	return &RateLimiter{interval: time.Minute / time.Duration(perMinute)}
+}
+
+// Wait blocks until the next request is allowed
+func (r *RateLimiter) Wait() {
+	r.mu.Lock()
+	now := time.Now()
+	if r.next.Before(now) {
+		r.next = now
+	}
+	delay := r.next.Sub(now)
+	r.next = r.next.Add(r.interval)
+	r.mu.Unlock()
+
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+}
+
+// Quota tracks API calls against a daily limit, resetting at local midnight
+type Quota struct {
+	mu    sync.Mutex
+	limit int
+	used  int
+	day   string
+}
+
+// NewQuota creates a quota allowing limit calls per day
+func NewQuota(limit int) *Quota {
+	return &Quota{limit: limit}
+}
+
+// Take records a call, returning ErrQuotaExceeded if the limit is reached
+func (q *Quota) Take() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.rollover()
+	if q.limit > 0 && q.used >= q.limit {
+		return ErrQuotaExceeded
+	}
+	q.used++
+	return nil
+}
+
+// Used returns the number of calls made today
+func (q *Quota) Used() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.rollover()
+	return q.used
+}
+
+// Limit returns the daily call limit (0 means unlimited)
+func (q *Quota) Limit() int {
+	return q.limit
+}
+
+// Remaining returns the number of calls left today, or -1 if unlimited
+func (q *Quota) Remaining() int {
+	if q.limit <= 0 {
+		return -1
+	}
+	return q.limit - q.Used()
+}
+
+// State returns the day the count applies to and the calls made that day
+func (q *Quota) State() (string, int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.rollover()
+	return q.day, q.used
+}
+
+// Restore carries over a count saved by State, so a restart doesn't reset
+// the quota. A count from an earlier day is ignored.
+func (q *Quota) Restore(day string, used int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.rollover()
+	if day == q.day && used > q.used {
+		q.used = used
+	}
+}
+
+// rollover resets the counter when the day changes; callers must hold mu
+func (q *Quota) rollover() {
+	today := time.Now().Format("2006-01-02")
+	if q.day != today {
+		q.day = today
+		q.used = 0
+	}
+}
@@ -65,20 +65,37 @@ func (c *Client) Search(params SearchParams) (*SearchResponse, error) {
 // SearchAll retrieves all results from a search, handling pagination
 func (c *Client) SearchAll(params SearchParams) ([]Availability, error) {
 	var allResults []Availability
+	err := c.SearchEach(params, func(page []Availability) error {
+		allResults = append(allResults, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return allResults, nil
+}
 
+// SearchEach pages through a search, passing each page to fn as it
+// arrives so callers can stream without holding every result. An error from
+// fn stops paging and is returned.
+func (c *Client) SearchEach(params SearchParams, fn func(page []Availability) error) error {
 	params.Take = 100 // Max per page
 	params.Skip = 0
 
 	for {
 		resp, err := c.Search(params)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		allResults = append(allResults, resp.Data...)
+		if len(resp.Data) > 0 {
+			if err := fn(resp.Data); err != nil {
+				return err
+			}
+		}
 
 		if !resp.HasMore || len(resp.Data) == 0 {
-			break
+			return nil
 		}
 
 		params.Skip += len(resp.Data)
@@ -86,6 +103,4 @@ func (c *Client) SearchAll(params SearchParams) ([]Availability, error) {
 			params.Cursor = resp.Cursor
 		}
 	}
-
-	return allResults, nil
 }
@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"sync"
 )
 
 // GetTrips retrieves trip details for an availability ID
@@ -15,3 +16,48 @@ func (c *Client) GetTrips(availabilityID string) (*TripsResponse, error) {
 
 	return &response, nil
 }
+
+// TripsResult is the outcome of fetching trips for one availability
+type TripsResult struct {
+	AvailabilityID string
+	Trips          []Trip
+	Err            error
+}
+
+// GetTripsBatch fetches trips for many availability IDs with up to workers
+// requests in flight. Requests still pass through the client's rate limiter
+// and quota. Results are returned in the order of ids.
+func (c *Client) GetTripsBatch(ids []string, workers int) []TripsResult {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]TripsResult, len(ids))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(ids); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result := TripsResult{AvailabilityID: ids[i]}
+				resp, err := c.GetTrips(ids[i])
+				if err != nil {
+					result.Err = err
+				} else {
+					result.Trips = resp.Data
+				}
+				results[i] = result
+			}
+		}()
+	}
+
+	for i := range ids {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
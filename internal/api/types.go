@@ -1,6 +1,10 @@
 package api
 
-import "time"
+import (
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Route represents a flight route
 type Route struct {
@@ -21,36 +25,111 @@ type Availability struct {
 	ParsedDate time.Time `json:"-"`
 
 	// Economy
-	YAvailable      bool   `json:"YAvailable"`
-	YMileageCost    string `json:"YMileageCost"`
-	YRemainingSeats int    `json:"YRemainingSeats"`
-	YAirlines       string `json:"YAirlines"`
-	YDirect         bool   `json:"YDirect"`
+	YAvailable            bool   `json:"YAvailable"`
+	YMileageCost          string `json:"YMileageCost"`
+	YRemainingSeats       int    `json:"YRemainingSeats"`
+	YAirlines             string `json:"YAirlines"`
+	YDirect               bool   `json:"YDirect"`
+	YDirectMileageCost    int    `json:"YDirectMileageCost"`
+	YDirectRemainingSeats int    `json:"YDirectRemainingSeats"`
+	YDirectAirlines       string `json:"YDirectAirlines"`
+	YTotalTaxes           int    `json:"YTotalTaxes"`
 
 	// Premium Economy
-	WAvailable      bool   `json:"WAvailable"`
-	WMileageCost    string `json:"WMileageCost"`
-	WRemainingSeats int    `json:"WRemainingSeats"`
-	WAirlines       string `json:"WAirlines"`
-	WDirect         bool   `json:"WDirect"`
+	WAvailable            bool   `json:"WAvailable"`
+	WMileageCost          string `json:"WMileageCost"`
+	WRemainingSeats       int    `json:"WRemainingSeats"`
+	WAirlines             string `json:"WAirlines"`
+	WDirect               bool   `json:"WDirect"`
+	WDirectMileageCost    int    `json:"WDirectMileageCost"`
+	WDirectRemainingSeats int    `json:"WDirectRemainingSeats"`
+	WDirectAirlines       string `json:"WDirectAirlines"`
+	WTotalTaxes           int    `json:"WTotalTaxes"`
 
 	// Business
-	JAvailable      bool   `json:"JAvailable"`
-	JMileageCost    string `json:"JMileageCost"`
-	JRemainingSeats int    `json:"JRemainingSeats"`
-	JAirlines       string `json:"JAirlines"`
-	JDirect         bool   `json:"JDirect"`
+	JAvailable            bool   `json:"JAvailable"`
+	JMileageCost          string `json:"JMileageCost"`
+	JRemainingSeats       int    `json:"JRemainingSeats"`
+	JAirlines             string `json:"JAirlines"`
+	JDirect               bool   `json:"JDirect"`
+	JDirectMileageCost    int    `json:"JDirectMileageCost"`
+	JDirectRemainingSeats int    `json:"JDirectRemainingSeats"`
+	JDirectAirlines       string `json:"JDirectAirlines"`
+	JTotalTaxes           int    `json:"JTotalTaxes"`
 
 	// First
-	FAvailable      bool   `json:"FAvailable"`
-	FMileageCost    string `json:"FMileageCost"`
-	FRemainingSeats int    `json:"FRemainingSeats"`
-	FAirlines       string `json:"FAirlines"`
-	FDirect         bool   `json:"FDirect"`
+	FAvailable            bool   `json:"FAvailable"`
+	FMileageCost          string `json:"FMileageCost"`
+	FRemainingSeats       int    `json:"FRemainingSeats"`
+	FAirlines             string `json:"FAirlines"`
+	FDirect               bool   `json:"FDirect"`
+	FDirectMileageCost    int    `json:"FDirectMileageCost"`
+	FDirectRemainingSeats int    `json:"FDirectRemainingSeats"`
+	FDirectAirlines       string `json:"FDirectAirlines"`
+	FTotalTaxes           int    `json:"FTotalTaxes"`
+
+	// Taxes are in minor units (e.g. cents) of TaxesCurrency
+	TaxesCurrency string `json:"TaxesCurrency"`
 
 	Source    string    `json:"Source"`
 	CreatedAt time.Time `json:"CreatedAt"`
 	UpdatedAt time.Time `json:"UpdatedAt"`
+
+	// Passengers is the party size the result is priced for; the API fields
+	// above stay per passenger. Not set by the API.
+	Passengers int `json:"Passengers,omitempty"`
+}
+
+// CabinAvailability is the per-cabin view of an Availability. Miles and
+// taxes are for the whole party when the availability has Passengers set.
+type CabinAvailability struct {
+	Cabin          string
+	Available      bool
+	MileageCost    string
+	Miles          int
+	RemainingSeats int
+	Airlines       string
+	Direct         bool
+	TotalTaxes     int
+
+	// Cheapest direct option, which may cost more than the overall cheapest
+	DirectMiles          int
+	DirectRemainingSeats int
+	DirectAirlines       string
+}
+
+// Cabin returns the availability details for a cabin code (Y, W, J or F)
+func (a Availability) Cabin(code string) CabinAvailability {
+	c := CabinAvailability{Cabin: code}
+	switch code {
+	case "Y":
+		c.Available, c.MileageCost, c.RemainingSeats, c.Airlines, c.Direct = a.YAvailable, a.YMileageCost, a.YRemainingSeats, a.YAirlines, a.YDirect
+		c.DirectMiles, c.DirectRemainingSeats, c.DirectAirlines = a.YDirectMileageCost, a.YDirectRemainingSeats, a.YDirectAirlines
+		c.TotalTaxes = a.YTotalTaxes
+	case "W":
+		c.Available, c.MileageCost, c.RemainingSeats, c.Airlines, c.Direct = a.WAvailable, a.WMileageCost, a.WRemainingSeats, a.WAirlines, a.WDirect
+		c.DirectMiles, c.DirectRemainingSeats, c.DirectAirlines = a.WDirectMileageCost, a.WDirectRemainingSeats, a.WDirectAirlines
+		c.TotalTaxes = a.WTotalTaxes
+	case "J":
+		c.Available, c.MileageCost, c.RemainingSeats, c.Airlines, c.Direct = a.JAvailable, a.JMileageCost, a.JRemainingSeats, a.JAirlines, a.JDirect
+		c.DirectMiles, c.DirectRemainingSeats, c.DirectAirlines = a.JDirectMileageCost, a.JDirectRemainingSeats, a.JDirectAirlines
+		c.TotalTaxes = a.JTotalTaxes
+	case "F":
+		c.Available, c.MileageCost, c.RemainingSeats, c.Airlines, c.Direct = a.FAvailable, a.FMileageCost, a.FRemainingSeats, a.FAirlines, a.FDirect
+		c.DirectMiles, c.DirectRemainingSeats, c.DirectAirlines = a.FDirectMileageCost, a.FDirectRemainingSeats, a.FDirectAirlines
+		c.TotalTaxes = a.FTotalTaxes
+	}
+	c.Miles, _ = strconv.Atoi(c.MileageCost)
+
+	if n := a.Passengers; n > 1 {
+		if c.Miles > 0 {
+			c.Miles *= n
+			c.MileageCost = strconv.Itoa(c.Miles)
+		}
+		c.DirectMiles *= n
+		c.TotalTaxes *= n
+	}
+	return c
 }
 
 // AvailabilitySegment represents a single flight segment
@@ -93,6 +172,21 @@ type Trip struct {
 	Source               string                `json:"Source"`
 	CreatedAt            time.Time             `json:"CreatedAt"`
 	UpdatedAt            time.Time             `json:"UpdatedAt"`
+
+	// Passengers is the party size the trip is priced for; MileageCost and
+	// TotalTaxes stay per passenger. Not set by the API.
+	Passengers int `json:"Passengers,omitempty"`
+}
+
+// PartyMiles returns the mileage cost for every passenger
+func (t Trip) PartyMiles() int {
+	return t.MileageCost * max(t.Passengers, 1)
+}
+
+// PartyTaxes returns the taxes for every passenger, in minor units of
+// TaxesCurrency
+func (t Trip) PartyTaxes() int {
+	return t.TotalTaxes * max(t.Passengers, 1)
 }
 
 // SearchResponse represents the response from the cached search endpoint
@@ -125,35 +219,35 @@ type RoutesResponse struct {
 
 // SearchParams contains parameters for cached search
 type SearchParams struct {
-	OriginAirports      []string
-	DestinationAirports []string
-	StartDate           string
-	EndDate             string
-	Cabin               string   // Y, W, J, F or empty for all
-	Sources             []string // Empty for all sources
-	DirectOnly          bool
-	Take                int
-	Skip                int
-	Cursor              int64
+	OriginAirports      []string `json:"origin_airports" desc:"Origin airport IATA codes, e.g. SFO"`
+	DestinationAirports []string `json:"destination_airports" desc:"Destination airport IATA codes, e.g. NRT"`
+	StartDate           string   `json:"start_date,omitempty" desc:"Earliest departure date (YYYY-MM-DD)"`
+	EndDate             string   `json:"end_date,omitempty" desc:"Latest departure date (YYYY-MM-DD)"`
+	Cabin               string   `json:"cabin,omitempty" desc:"Cabin: economy, premium, business or first; empty for all"`
+	Sources             []string `json:"sources,omitempty" desc:"Mileage program sources, e.g. aeroplan; empty for all"`
+	DirectOnly          bool     `json:"direct_only,omitempty" desc:"Only direct flights"`
+	Take                int      `json:"take,omitempty"`
+	Skip                int      `json:"skip,omitempty"`
+	Cursor              int64    `json:"cursor,omitempty"`
 }
 
 // AvailabilityParams contains parameters for bulk availability
 type AvailabilityParams struct {
-	Source       string
-	Cabin        string
-	OriginRegion string
-	DestRegion   string
-	StartDate    string
-	EndDate      string
-	Take         int
-	Skip         int
-	Cursor       int64
+	Source       string `json:"source" desc:"Mileage program source, e.g. aeroplan"`
+	Cabin        string `json:"cabin,omitempty" desc:"Cabin: economy, premium, business or first; empty for all"`
+	OriginRegion string `json:"origin_region,omitempty" desc:"Origin region, e.g. North America"`
+	DestRegion   string `json:"destination_region,omitempty" desc:"Destination region, e.g. Asia"`
+	StartDate    string `json:"start_date,omitempty" desc:"Earliest departure date (YYYY-MM-DD)"`
+	EndDate      string `json:"end_date,omitempty" desc:"Latest departure date (YYYY-MM-DD)"`
+	Take         int    `json:"take,omitempty"`
+	Skip         int    `json:"skip,omitempty"`
+	Cursor       int64  `json:"cursor,omitempty"`
 }
 
 // RoutesParams contains parameters for routes endpoint
 type RoutesParams struct {
-	Source string
-	Origin string
+	Source string `json:"source,omitempty" desc:"Mileage program source, e.g. united"`
+	Origin string `json:"origin,omitempty" desc:"Origin airport IATA code"`
 }
 
 // CabinClass represents cabin class codes
@@ -171,6 +265,43 @@ func ValidCabins() []string {
 	return []string{"Y", "W", "J", "F"}
 }
 
+// CabinParam converts a cabin code (Y/W/J/F) or name to the value the API
+// expects (economy/premium/business/first). Unknown values are lowercased.
+func CabinParam(cabin string) string {
+	cabin = strings.TrimSpace(cabin)
+	switch strings.ToUpper(cabin) {
+	case "":
+		return ""
+	case "Y", "ECONOMY":
+		return "economy"
+	case "W", "PREMIUM":
+		return "premium"
+	case "J", "BUSINESS":
+		return "business"
+	case "F", "FIRST":
+		return "first"
+	default:
+		return strings.ToLower(cabin)
+	}
+}
+
+// CabinCode converts a cabin name or code to its single-letter code
+// (Y/W/J/F). Unknown values are returned uppercased.
+func CabinCode(cabin string) string {
+	switch c := strings.ToUpper(strings.TrimSpace(cabin)); c {
+	case "ECONOMY":
+		return "Y"
+	case "PREMIUM":
+		return "W"
+	case "BUSINESS":
+		return "J"
+	case "FIRST":
+		return "F"
+	default:
+		return c
+	}
+}
+
 // ValidSources returns all valid mileage program sources
 func ValidSources() []string {
 	return []string{
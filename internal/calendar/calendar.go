@@ -0,0 +1,140 @@
+package calendar
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// Day is the best availability for one date in one cabin
+type Day struct {
+	Date      string `json:"date"`
+	Available bool   `json:"available"`
+	Miles     int    `json:"miles,omitempty"`
+	Seats     int    `json:"seats,omitempty"`
+	Source    string `json:"source,omitempty"`
+	Route     string `json:"route,omitempty"`
+	Direct    bool   `json:"direct,omitempty"`
+	Level     int    `json:"level"` // 0 unavailable, 1 cheapest third ... 3 most expensive third
+}
+
+// Month is a calendar month of days
+type Month struct {
+	Year  int        `json:"year"`
+	Month time.Month `json:"month"`
+	Name  string     `json:"name"`
+	Days  []Day      `json:"days"`
+}
+
+// FirstWeekday returns the weekday of the first day of the month
+func (m Month) FirstWeekday() time.Weekday {
+	return time.Date(m.Year, m.Month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+}
+
+// Calendar is a price heatmap over one or more months
+type Calendar struct {
+	Cabin        string   `json:"cabin"`
+	Origins      []string `json:"origins"`
+	Destinations []string `json:"destinations"`
+	MinMiles     int      `json:"minMiles"`
+	MaxMiles     int      `json:"maxMiles"`
+	Months       []Month  `json:"months"`
+}
+
+// ParseMonth parses a YYYY-MM month
+func ParseMonth(s string) (time.Time, error) {
+	t, err := time.Parse("2006-01", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid month %q (use YYYY-MM)", s)
+	}
+	return t, nil
+}
+
+// Range returns the first and last dates covered by the months, as YYYY-MM-DD
+func Range(months []time.Time) (string, string) {
+	sorted := append([]time.Time(nil), months...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	first := sorted[0]
+	last := sorted[len(sorted)-1].AddDate(0, 1, -1)
+	return first.Format("2006-01-02"), last.Format("2006-01-02")
+}
+
+// Build computes the best price per day for a cabin (Y, W, J or F) across
+// the given months
+func Build(results []api.Availability, cabin string, months []time.Time, origins, destinations []string) Calendar {
+	best := map[string]Day{}
+	for _, a := range results {
+		c := a.Cabin(cabin)
+		if !c.Available || c.Miles == 0 {
+			continue
+		}
+		day, ok := best[a.Date]
+		if ok && day.Miles < c.Miles {
+			continue
+		}
+		// On a tie prefer the option with more seats
+		if ok && day.Miles == c.Miles && day.Seats >= c.RemainingSeats {
+			continue
+		}
+		best[a.Date] = Day{
+			Date:      a.Date,
+			Available: true,
+			Miles:     c.Miles,
+			Seats:     c.RemainingSeats,
+			Source:    a.Source,
+			Route:     a.Route.OriginAirport + "-" + a.Route.DestinationAirport,
+			Direct:    c.Direct,
+		}
+	}
+
+	cal := Calendar{Cabin: cabin, Origins: origins, Destinations: destinations}
+	for _, day := range best {
+		if cal.MinMiles == 0 || day.Miles < cal.MinMiles {
+			cal.MinMiles = day.Miles
+		}
+		if day.Miles > cal.MaxMiles {
+			cal.MaxMiles = day.Miles
+		}
+	}
+
+	sorted := append([]time.Time(nil), months...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	for _, m := range sorted {
+		month := Month{Year: m.Year(), Month: m.Month(), Name: m.Format("January 2006")}
+		for d := m; d.Month() == m.Month(); d = d.AddDate(0, 0, 1) {
+			date := d.Format("2006-01-02")
+			day, ok := best[date]
+			if !ok {
+				day = Day{Date: date}
+			}
+			day.Level = cal.level(day)
+			month.Days = append(month.Days, day)
+		}
+		cal.Months = append(cal.Months, month)
+	}
+
+	return cal
+}
+
+// level buckets a day's price into thirds of the calendar's price range
+func (c Calendar) level(d Day) int {
+	if !d.Available {
+		return 0
+	}
+	spread := c.MaxMiles - c.MinMiles
+	if spread == 0 {
+		return 1
+	}
+	switch pos := float64(d.Miles-c.MinMiles) / float64(spread); {
+	case pos < 1.0/3:
+		return 1
+	case pos < 2.0/3:
+		return 2
+	default:
+		return 3
+	}
+}
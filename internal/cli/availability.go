@@ -18,10 +18,24 @@ var availabilityCmd = &cobra.Command{
 
 This is useful for exploring availability across many routes at once.
 
+Map outputs (geojson, kml) place airports from a bundled list of about 170
+major airports; routes touching any other airport are left off the map and
+listed on stderr.
+
 Examples:
   seats availability --source aeroplan
   seats availability --source united --cabin J,F
-  seats availability --source delta --origin-region north-america --dest-region europe`,
+  seats availability --source delta --origin-region north-america --dest-region europe
+  seats availability --source aeroplan --cabin J --tui
+  seats availability --source aeroplan --cabin J --passengers 2
+  seats availability --source aeroplan --output ndjson > availability.ndjson
+  seats availability --source aeroplan --fields date,route,J.miles,J.seats
+  seats availability --source aeroplan --output xlsx --out aeroplan.xlsx
+  seats availability --source aeroplan --cabin J --output sqlite --out awards.db
+  seats availability --source aeroplan --cabin J --output kml > aeroplan-j.kml
+
+With --output ndjson every page of results is fetched and written one record
+per line as it arrives.`,
 	RunE: runAvailability,
 }
 
@@ -33,6 +47,14 @@ var (
 	availStartDate    string
 	availEndDate      string
 	availOutput       string
+	availTUI          bool
+	availRankBy       string
+	availPax          int
+	availEnvelope     bool
+	availTemplate     string
+	availTmplFile     string
+	availFields       string
+	availOutFile      string
 )
 
 func init() {
@@ -44,9 +66,19 @@ func init() {
 	availabilityCmd.Flags().StringVar(&availDestRegion, "dest-region", "", "Destination region filter")
 	availabilityCmd.Flags().StringVar(&availStartDate, "start-date", "", "Start date (YYYY-MM-DD)")
 	availabilityCmd.Flags().StringVar(&availEndDate, "end-date", "", "End date (YYYY-MM-DD)")
-	availabilityCmd.Flags().StringVarP(&availOutput, "output", "o", "table", "Output format: table, json, csv")
+	availabilityCmd.Flags().StringVarP(&availOutput, "output", "o", "table", "Output format: table, json, csv, ndjson, markdown, html, template, xlsx, sqlite, geojson, kml")
+	availabilityCmd.Flags().BoolVar(&availEnvelope, "envelope", false, "With ndjson output, write a first line with the query metadata")
+	availabilityCmd.Flags().StringVar(&availOutFile, "out", "", "File to write xlsx or sqlite output to")
+	availabilityCmd.Flags().StringVar(&availTemplate, "template", "", templateHelp)
+	availabilityCmd.Flags().StringVar(&availTmplFile, "template-file", "", "File containing the --output template")
+	availabilityCmd.Flags().StringVar(&availFields, "fields", "", "Columns for table, csv and json output: "+export.FieldNames())
+	availabilityCmd.Flags().BoolVar(&availTUI, "tui", false, "Browse results in a full-screen interactive table")
+	availabilityCmd.Flags().IntVar(&availPax, "passengers", 1, "Number of passengers; drops options without enough seats and prices for the party")
+	availabilityCmd.Flags().StringVar(&availRankBy, "rank-by", "", "Rank results by: value, miles, date (default: API order)")
 
 	availabilityCmd.MarkFlagRequired("source")
+	availabilityCmd.MarkFlagsMutuallyExclusive("tui", "output")
+	availabilityCmd.MarkFlagsMutuallyExclusive("tui", "fields")
 }
 
 func runAvailability(cmd *cobra.Command, args []string) error {
@@ -59,6 +91,22 @@ func runAvailability(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if err := validatePassengers(availPax); err != nil {
+		return err
+	}
+
+	if err := checkOutFile(availOutFile, availOutput); err != nil {
+		return err
+	}
+
+	if err := checkFields(availFields, availOutput); err != nil {
+		return err
+	}
+
+	if err := checkTemplate(availTemplate, availTmplFile, availOutput); err != nil {
+		return err
+	}
+
 	client := api.NewClient(cfg.GetAPIKey())
 
 	params := api.AvailabilityParams{
@@ -70,24 +118,95 @@ func runAvailability(cmd *cobra.Command, args []string) error {
 		EndDate:      availEndDate,
 	}
 
+	var cabins []string
+	for _, c := range parseCSV(availCabin) {
+		cabins = append(cabins, api.CabinCode(c))
+	}
+
+	if isNDJSON(availOutput) {
+		return streamAvailability(client, params, cabins)
+	}
+
 	resp, err := client.GetAvailability(params)
 	if err != nil {
 		return fmt.Errorf("get availability failed: %w", err)
 	}
 
+	results, combos := partyResults(resp.Data, availPax, cabins)
+	if err := rankResults(results, availRankBy, pointValues(), cabins); err != nil {
+		return err
+	}
+
+	if availTUI {
+		printPartySuggestions(os.Stdout, combos, availPax)
+		return browseResults(client, results, availPax)
+	}
+
+	if availFields != "" {
+		printPartySuggestions(os.Stderr, combos, availPax)
+		return writeFields(results, availFields, availOutput)
+	}
+
 	switch strings.ToLower(availOutput) {
 	case "json":
-		return export.ToJSON(os.Stdout, resp.Data, true)
+		printPartySuggestions(os.Stderr, combos, availPax)
+		return export.WriteJSON(os.Stdout, walletJSON(results, cabins), true)
 	case "csv":
-		return export.ToCSV(os.Stdout, resp.Data)
+		printPartySuggestions(os.Stderr, combos, availPax)
+		return export.ToCSV(os.Stdout, results, pointValues(), walletColumns(cabins)...)
+	case "markdown", "md":
+		printPartySuggestions(os.Stderr, combos, availPax)
+		return export.ToMarkdown(os.Stdout, results, availabilitySummary(), pointValues())
+	case "html":
+		printPartySuggestions(os.Stderr, combos, availPax)
+		return export.ToHTML(os.Stdout, results, availabilitySummary(), pointValues())
+	case "xlsx":
+		printPartySuggestions(os.Stderr, combos, availPax)
+		return writeXLSX(availOutFile, export.Workbook{Availability: results})
+	case "sqlite":
+		printPartySuggestions(os.Stderr, combos, availPax)
+		target := outputTarget{path: availOutFile, command: "availability", query: availabilityQuery(params), passengers: availPax}
+		return writeSQLite(target, export.Workbook{Availability: results})
+	case "template":
+		printPartySuggestions(os.Stderr, combos, availPax)
+		return writeTemplate(availTemplate, availTmplFile, results)
+	case "geojson", "kml":
+		printPartySuggestions(os.Stderr, combos, availPax)
+		lines, missing := export.AvailabilityLines(results, pointValues())
+		return writeMap(availOutput, mapName("availability", availSource, availCabin), lines, missing)
 	default:
-		printAvailabilityResults(resp.Data)
+		printAvailabilityResults(results, cabins)
+		printPartySuggestions(os.Stdout, combos, availPax)
 	}
 
 	return nil
 }
 
-func printAvailabilityResults(results []api.Availability) {
+// streamAvailability writes every page of availability as NDJSON as it arrives
+func streamAvailability(client *api.Client, params api.AvailabilityParams, cabins []string) error {
+	if err := checkStreamRanking(availRankBy); err != nil {
+		return err
+	}
+
+	out, err := newNDJSONOutput(availEnvelope, "availability", availabilityQuery(params))
+	if err != nil {
+		return err
+	}
+
+	stream := availabilityStream{client: client, passengers: availPax, cabins: cabins}
+	return client.GetAvailabilityEach(params, stream.write(out))
+}
+
+// availabilityQuery describes an availability pull for NDJSON envelopes and
+// SQLite fetch records
+func availabilityQuery(params api.AvailabilityParams) interface{} {
+	return struct {
+		api.AvailabilityParams
+		Passengers int `json:"passengers"`
+	}{params, availPax}
+}
+
+func printAvailabilityResults(results []api.Availability, cabins []string) {
 	if len(results) == 0 {
 		fmt.Println("No results found.")
 		return
@@ -95,18 +214,25 @@ func printAvailabilityResults(results []api.Availability) {
 
 	fmt.Printf("Found %d results:\n\n", len(results))
 
+	vals := pointValues()
+	w := userWallet()
+
 	// Print header
-	fmt.Printf("%-12s %-5s %-5s %-15s %-8s %-8s %-8s %-8s\n",
-		"Date", "From", "To", "Source", "Y", "W", "J", "F")
-	fmt.Println(strings.Repeat("-", 80))
+	header := fmt.Sprintf("%-12s %-5s %-5s %-15s %-8s %-8s %-8s %-8s %-8s",
+		"Date", "From", "To", "Source", "Y", "W", "J", "F", "Value")
+	if !w.IsEmpty() {
+		header += " Bookable"
+	}
+	fmt.Println(header)
+	fmt.Println(strings.Repeat("-", 90))
 
 	for _, a := range results {
-		yInfo := formatCabinInfo(a.YAvailable, a.YMileageCost, a.YRemainingSeats)
-		wInfo := formatCabinInfo(a.WAvailable, a.WMileageCost, a.WRemainingSeats)
-		jInfo := formatCabinInfo(a.JAvailable, a.JMileageCost, a.JRemainingSeats)
-		fInfo := formatCabinInfo(a.FAvailable, a.FMileageCost, a.FRemainingSeats)
+		yInfo := formatCabin(a, "Y")
+		wInfo := formatCabin(a, "W")
+		jInfo := formatCabin(a, "J")
+		fInfo := formatCabin(a, "F")
 
-		fmt.Printf("%-12s %-5s %-5s %-15s %-8s %-8s %-8s %-8s\n",
+		row := fmt.Sprintf("%-12s %-5s %-5s %-15s %-8s %-8s %-8s %-8s %-8s",
 			a.Date,
 			a.Route.OriginAirport,
 			a.Route.DestinationAirport,
@@ -115,6 +241,11 @@ func printAvailabilityResults(results []api.Availability) {
 			wInfo,
 			jInfo,
 			fInfo,
+			formatValueCell(vals, a, cabins),
 		)
+		if !w.IsEmpty() {
+			row += " " + formatBookable(w, a, cabins)
+		}
+		fmt.Println(row)
 	}
 }
@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/JHill6253/seats-aero-cli/internal/analysis"
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/export"
+)
+
+var bestCmd = &cobra.Command{
+	Use:   "best",
+	Short: "Summarize the best options per cabin across a date window",
+	Long: `Run a search and report, for each cabin, the lowest-miles dates, the
+cheapest program per route, the best direct and connecting alternatives and
+the seats left at each price.
+
+Examples:
+  seats best --from SFO --to NRT --start-date 2025-06-01 --end-date 2025-06-30
+  seats best --from SFO,LAX --to NRT,HND --cabin J,F
+  seats best --from SFO --to NRT --source aeroplan,united --output json`,
+	RunE: runBest,
+}
+
+var (
+	bestFrom      string
+	bestTo        string
+	bestStartDate string
+	bestEndDate   string
+	bestCabin     string
+	bestSource    string
+	bestDirect    bool
+	bestLimit     int
+	bestOutput    string
+)
+
+func init() {
+	rootCmd.AddCommand(bestCmd)
+
+	bestCmd.Flags().StringVar(&bestFrom, "from", "", "Origin airport(s), comma-separated (required)")
+	bestCmd.Flags().StringVar(&bestTo, "to", "", "Destination airport(s), comma-separated (required)")
+	bestCmd.Flags().StringVar(&bestStartDate, "start-date", "", "Start date (YYYY-MM-DD)")
+	bestCmd.Flags().StringVar(&bestEndDate, "end-date", "", "End date (YYYY-MM-DD)")
+	bestCmd.Flags().StringVar(&bestCabin, "cabin", "", "Cabin class(es): Y, W, J, F, comma-separated (default: all)")
+	bestCmd.Flags().StringVar(&bestSource, "source", "", "Mileage program source(s), comma-separated")
+	bestCmd.Flags().BoolVar(&bestDirect, "direct-only", false, "Only consider direct flights")
+	bestCmd.Flags().IntVar(&bestLimit, "limit", 5, "Maximum dates and routes to list per cabin in the table")
+	bestCmd.Flags().StringVarP(&bestOutput, "output", "o", "table", "Output format: table, json")
+
+	bestCmd.MarkFlagRequired("from")
+	bestCmd.MarkFlagRequired("to")
+}
+
+func runBest(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if cfg == nil {
+		return fmt.Errorf("configuration not loaded")
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	var cabins []string
+	for _, c := range parseCSV(bestCabin) {
+		code := api.CabinCode(c)
+		if !isValidCabin(code) {
+			return fmt.Errorf("invalid cabin %q (use Y, W, J or F)", c)
+		}
+		cabins = append(cabins, code)
+	}
+
+	client := api.NewClient(cfg.GetAPIKey())
+
+	params := api.SearchParams{
+		OriginAirports:      parseCSV(bestFrom),
+		DestinationAirports: parseCSV(bestTo),
+		StartDate:           bestStartDate,
+		EndDate:             bestEndDate,
+		Sources:             parseCSV(bestSource),
+		DirectOnly:          bestDirect,
+	}
+	// The API filters on a single cabin; with several, filter locally
+	if len(cabins) == 1 {
+		params.Cabin = cabinCodeToName(cabins[0])
+	}
+
+	results, err := client.SearchAll(params)
+	if err != nil {
+		return fmt.Errorf("search failed: %w", err)
+	}
+
+	best := analysis.Summarize(params, results, cabins)
+
+	switch strings.ToLower(bestOutput) {
+	case "json":
+		return export.WriteJSON(os.Stdout, best, true)
+	default:
+		printBest(best, bestLimit)
+	}
+
+	return nil
+}
+
+func printBest(best analysis.Best, limit int) {
+	if len(best.Cabins) == 0 {
+		fmt.Println("No results found.")
+		return
+	}
+
+	fmt.Printf("Best options across %d results:\n", best.Results)
+
+	for _, cb := range best.Cabins {
+		fmt.Println()
+		fmt.Println(titleStyle.Render(fmt.Sprintf("%s · %d options · from %s miles",
+			api.CabinDisplayName(cb.Cabin), cb.Options, formatMilesK(cb.LowestMiles))))
+
+		fmt.Printf("%-12s %-9s %-22s %-8s %-6s %s\n", "Date", "Route", "Source", "Miles", "Seats", "Direct")
+		fmt.Println(strings.Repeat("-", 70))
+		for i, o := range cb.LowestDates {
+			if i == limit {
+				fmt.Printf("... and %d more dates\n", len(cb.LowestDates)-limit)
+				break
+			}
+			direct := "no"
+			if o.Direct {
+				direct = "yes"
+			}
+			fmt.Printf("%-12s %-9s %-22s %-8s %-6d %s\n",
+				o.Date, o.Route, api.SourceDisplayName(o.Source), formatMilesK(o.Miles), o.Seats, direct)
+		}
+
+		fmt.Println()
+		fmt.Println(formatBestAlternative("Best direct:", cb.BestDirect))
+		fmt.Println(formatBestAlternative("Best connecting:", cb.BestConnecting))
+
+		fmt.Println()
+		fmt.Printf("%-9s %-22s %-8s %-12s %-6s %s\n", "Route", "Cheapest program", "Miles", "Date", "Seats", "Programs")
+		fmt.Println(strings.Repeat("-", 70))
+		for i, rb := range cb.Routes {
+			if i == limit {
+				fmt.Printf("... and %d more routes\n", len(cb.Routes)-limit)
+				break
+			}
+			fmt.Printf("%-9s %-22s %-8s %-12s %-6d %d\n",
+				rb.Route, api.SourceDisplayName(rb.Best.Source), formatMilesK(rb.Best.Miles),
+				rb.Best.Date, rb.Best.Seats, rb.Programs)
+		}
+	}
+}
+
+func formatBestAlternative(label string, o *analysis.Option) string {
+	if o == nil {
+		return fmt.Sprintf("%-17s none", label)
+	}
+	return fmt.Sprintf("%-17s %s miles on %s, %s via %s (%d seats)",
+		label, formatMilesK(o.Miles), o.Date, o.Route, api.SourceDisplayName(o.Source), o.Seats)
+}
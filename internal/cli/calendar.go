@@ -0,0 +1,216 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/calendar"
+	"github.com/JHill6253/seats-aero-cli/internal/export"
+)
+
+var calendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "Show a month calendar heatmap of award prices",
+	Long: `Render a month grid with each day colored by the best mileage price
+for a cabin, along with the seats remaining at that price.
+
+Green days are in the cheapest third of the prices found, yellow the middle
+third and red the most expensive. Days without availability are left blank.
+
+Examples:
+  seats calendar --from SFO --to NRT --cabin J --month 2025-06
+  seats calendar --from SFO --to NRT,HND --cabin F --month 2025-06,2025-07
+  seats calendar --from SFO --to NRT --cabin J --month 2025-06 --months 3
+  seats calendar --from SFO --to NRT --cabin J --month 2025-06 --output html > june.html`,
+	RunE: runCalendar,
+}
+
+var (
+	calendarFrom   string
+	calendarTo     string
+	calendarCabin  string
+	calendarMonth  string
+	calendarMonths int
+	calendarSource string
+	calendarDirect bool
+	calendarOutput string
+)
+
+func init() {
+	rootCmd.AddCommand(calendarCmd)
+
+	calendarCmd.Flags().StringVar(&calendarFrom, "from", "", "Origin airport(s), comma-separated (required)")
+	calendarCmd.Flags().StringVar(&calendarTo, "to", "", "Destination airport(s), comma-separated (required)")
+	calendarCmd.Flags().StringVar(&calendarCabin, "cabin", "J", "Cabin class: Y/economy, W/premium, J/business, F/first")
+	calendarCmd.Flags().StringVar(&calendarMonth, "month", "", "Month(s) to show as YYYY-MM, comma-separated (default: this month)")
+	calendarCmd.Flags().IntVar(&calendarMonths, "months", 1, "Number of consecutive months to show from the first --month")
+	calendarCmd.Flags().StringVar(&calendarSource, "source", "", "Mileage program source(s), comma-separated")
+	calendarCmd.Flags().BoolVar(&calendarDirect, "direct-only", false, "Only consider direct flights")
+	calendarCmd.Flags().StringVarP(&calendarOutput, "output", "o", "table", "Output format: table, json, html")
+
+	calendarCmd.MarkFlagRequired("from")
+	calendarCmd.MarkFlagRequired("to")
+}
+
+func runCalendar(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if cfg == nil {
+		return fmt.Errorf("configuration not loaded")
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	cabin := api.CabinCode(calendarCabin)
+	if !isValidCabin(cabin) {
+		return fmt.Errorf("invalid cabin %q (use Y, W, J or F)", calendarCabin)
+	}
+
+	months, err := calendarMonthList(calendarMonth, calendarMonths, time.Now())
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg.GetAPIKey())
+
+	start, end := calendar.Range(months)
+	params := api.SearchParams{
+		OriginAirports:      parseCSV(calendarFrom),
+		DestinationAirports: parseCSV(calendarTo),
+		StartDate:           start,
+		EndDate:             end,
+		Cabin:               cabinCodeToName(cabin),
+		Sources:             parseCSV(calendarSource),
+		DirectOnly:          calendarDirect,
+	}
+
+	// A calendar needs every day covered, so fetch all pages
+	results, err := client.SearchAll(params)
+	if err != nil {
+		return fmt.Errorf("search failed: %w", err)
+	}
+
+	cal := calendar.Build(results, cabin, months, params.OriginAirports, params.DestinationAirports)
+
+	switch strings.ToLower(calendarOutput) {
+	case "json":
+		return export.CalendarToJSON(os.Stdout, cal, true)
+	case "html":
+		return export.CalendarToHTML(os.Stdout, cal)
+	default:
+		printCalendar(cal)
+	}
+
+	return nil
+}
+
+// calendarMonthList expands the --month and --months flags into the first
+// day of each month to show
+func calendarMonthList(spec string, count int, now time.Time) ([]time.Time, error) {
+	var months []time.Time
+	for _, s := range strings.Split(spec, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		m, err := calendar.ParseMonth(s)
+		if err != nil {
+			return nil, err
+		}
+		months = append(months, m)
+	}
+	if len(months) == 0 {
+		months = append(months, time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC))
+	}
+
+	// --months extends a single starting month
+	if len(months) == 1 && count > 1 {
+		for i := 1; i < count; i++ {
+			months = append(months, months[0].AddDate(0, i, 0))
+		}
+	}
+
+	return months, nil
+}
+
+func isValidCabin(code string) bool {
+	for _, c := range api.ValidCabins() {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	calendarCellStyle = lipgloss.NewStyle().Width(9).Height(2).Padding(0, 1)
+
+	calendarLevelStyles = []lipgloss.Style{
+		calendarCellStyle.Foreground(lipgloss.Color("#6B7280")),
+		calendarCellStyle.Background(lipgloss.Color("#16A34A")).Foreground(lipgloss.Color("#FFFFFF")),
+		calendarCellStyle.Background(lipgloss.Color("#CA8A04")).Foreground(lipgloss.Color("#FFFFFF")),
+		calendarCellStyle.Background(lipgloss.Color("#DC2626")).Foreground(lipgloss.Color("#FFFFFF")),
+	}
+)
+
+func printCalendar(cal calendar.Calendar) {
+	fmt.Println(titleStyle.Render(fmt.Sprintf("%s → %s · %s",
+		strings.Join(cal.Origins, ","), strings.Join(cal.Destinations, ","), api.CabinDisplayName(cal.Cabin))))
+
+	if cal.MaxMiles == 0 {
+		fmt.Println("No availability found.")
+		fmt.Println()
+	} else {
+		fmt.Println(subtitleStyle.Render(fmt.Sprintf("Best price per day: %s – %s miles",
+			formatMilesK(cal.MinMiles), formatMilesK(cal.MaxMiles))))
+		fmt.Println()
+	}
+
+	for _, m := range cal.Months {
+		fmt.Println(lipgloss.NewStyle().Bold(true).Render(m.Name))
+
+		header := make([]string, 7)
+		for i, name := range []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"} {
+			header[i] = calendarCellStyle.Height(1).Foreground(lipgloss.Color("#6B7280")).Render(name)
+		}
+		fmt.Println(lipgloss.JoinHorizontal(lipgloss.Top, header...))
+
+		week := make([]string, 0, 7)
+		for i := 0; i < int(m.FirstWeekday()); i++ {
+			week = append(week, calendarCellStyle.Render(""))
+		}
+		for i, day := range m.Days {
+			week = append(week, calendarDayCell(i+1, day))
+			if len(week) == 7 {
+				fmt.Println(lipgloss.JoinHorizontal(lipgloss.Top, week...))
+				week = week[:0]
+			}
+		}
+		if len(week) > 0 {
+			fmt.Println(lipgloss.JoinHorizontal(lipgloss.Top, week...))
+		}
+		fmt.Println()
+	}
+}
+
+func calendarDayCell(num int, day calendar.Day) string {
+	text := fmt.Sprintf("%d", num)
+	if day.Available {
+		text += fmt.Sprintf("\n%s·%d", formatMilesK(day.Miles), day.Seats)
+	}
+	return calendarLevelStyles[day.Level].Render(text)
+}
+
+// formatMilesK renders a mileage price in thousands, e.g. 70000 as "70k"
+func formatMilesK(miles int) string {
+	if miles%1000 == 0 {
+		return fmt.Sprintf("%dk", miles/1000)
+	}
+	return fmt.Sprintf("%.1fk", float64(miles)/1000)
+}
@@ -0,0 +1,314 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/JHill6253/seats-aero-cli/internal/airports"
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/connect"
+	"github.com/JHill6253/seats-aero-cli/internal/export"
+	"github.com/JHill6253/seats-aero-cli/internal/filter"
+	"github.com/JHill6253/seats-aero-cli/internal/valuation"
+)
+
+var connectCmd = &cobra.Command{
+	Use:   "connect",
+	Short: "Build connecting itineraries from two separate awards",
+	Long: `Pair two separately booked awards that connect at a gateway airport, for
+when no through award exists (e.g. SFO→YVR on one program and YVR→NRT on
+another).
+
+With --via auto, gateways are found from the routes of the --source programs,
+which are required: airports with a route from the origin and a route on to
+the destination, ordered by how far out of the way they are. Each leg is
+searched, trips are fetched for every result, and legs are paired when the
+onward flight leaves the gateway at least --min-connection after the first
+lands. By default both awards must be on the same local day at the gateway;
+--next-day also allows leaving the following day. The onward leg is searched
+through the day after --end-date, for first legs that land after midnight.
+
+This is expensive in API calls: one routes call per program with --via auto,
+a search per leg (more for many results), then one trips call per result on
+each leg. All of them count against your daily quota and are spaced out by
+--rate-limit.
+
+Separate awards are separate tickets: a missed connection is not protected,
+so leave a generous buffer.
+
+Examples:
+  seats connect --from SFO --to NRT --via YVR --start-date 2024-06-01 --end-date 2024-06-07
+  seats connect --from SFO --to NRT --via YVR,SEA --cabin J --min-connection 3h
+  seats connect --from SFO --to NRT --via auto --source aeroplan,alaska --next-day
+  seats connect --from SFO --to NRT --source aeroplan --rank-by value --output json`,
+	RunE: runConnect,
+}
+
+var (
+	connectFrom       string
+	connectTo         string
+	connectVia        string
+	connectStartDate  string
+	connectEndDate    string
+	connectCabin      string
+	connectSource     string
+	connectMinConn    time.Duration
+	connectMaxConn    time.Duration
+	connectNextDay    bool
+	connectMaxVia     int
+	connectMaxDetour  float64
+	connectPax        int
+	connectRankBy     string
+	connectLimit      int
+	connectOutput     string
+	connectTripsConc  int
+	connectTripsLimit int
+)
+
+func init() {
+	rootCmd.AddCommand(connectCmd)
+
+	connectCmd.Flags().StringVar(&connectFrom, "from", "", "Origin airport (required)")
+	connectCmd.Flags().StringVar(&connectTo, "to", "", "Destination airport (required)")
+	connectCmd.Flags().StringVar(&connectVia, "via", "auto", "Connecting airports, comma-separated, or auto to find them from routes")
+	connectCmd.Flags().StringVar(&connectStartDate, "start-date", "", "Start date for the first leg (YYYY-MM-DD)")
+	connectCmd.Flags().StringVar(&connectEndDate, "end-date", "", "End date for the first leg (YYYY-MM-DD)")
+	connectCmd.Flags().StringVar(&connectCabin, "cabin", "", "Cabin class for both legs: Y/economy, W/premium, J/business, F/first")
+	connectCmd.Flags().StringVar(&connectSource, "source", "", "Mileage program source(s) for both legs, comma-separated")
+	connectCmd.Flags().DurationVar(&connectMinConn, "min-connection", 2*time.Hour, "Minimum time between landing and the onward departure")
+	connectCmd.Flags().DurationVar(&connectMaxConn, "max-connection", 0, "Maximum time between landing and the onward departure, e.g. 12h")
+	connectCmd.Flags().BoolVar(&connectNextDay, "next-day", false, "Allow the onward award to leave the day after arrival")
+	connectCmd.Flags().IntVar(&connectMaxVia, "max-via", 5, "With --via auto, the most gateways to search")
+	connectCmd.Flags().Float64Var(&connectMaxDetour, "max-detour", 1.5, "With --via auto, skip gateways whose total distance is more than this multiple of the direct distance")
+	connectCmd.Flags().IntVar(&connectPax, "passengers", 1, "Number of passengers; both awards must seat the party")
+	connectCmd.Flags().StringVar(&connectRankBy, "rank-by", "", "Rank itineraries by: value, miles, connection (default: miles)")
+	connectCmd.Flags().IntVar(&connectLimit, "limit", 20, "Maximum itineraries to show (0 for all)")
+	connectCmd.Flags().StringVarP(&connectOutput, "output", "o", "table", "Output format: table, json")
+	connectCmd.Flags().IntVar(&connectTripsConc, "concurrency", defaultTripsConcurrency, "Trip lookups to run in parallel")
+	connectCmd.Flags().IntVar(&connectTripsLimit, "rate-limit", defaultTripsRateLimit, "Maximum API calls per minute, including route and search calls")
+
+	connectCmd.MarkFlagRequired("from")
+	connectCmd.MarkFlagRequired("to")
+}
+
+func runConnect(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if cfg == nil {
+		return fmt.Errorf("configuration not loaded")
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	if err := validatePassengers(connectPax); err != nil {
+		return err
+	}
+
+	cabin := ""
+	if connectCabin != "" {
+		cabin = api.CabinCode(connectCabin)
+		if !isValidCabin(cabin) {
+			return fmt.Errorf("invalid cabin %q (use Y, W, J or F)", connectCabin)
+		}
+	}
+
+	from, to := strings.ToUpper(connectFrom), strings.ToUpper(connectTo)
+	sources := parseCSV(connectSource)
+	if isAutoVia() && len(sources) == 0 {
+		return fmt.Errorf("--via auto needs --source to choose the programs whose routes are loaded, or list the gateways with --via")
+	}
+
+	// The limiter covers route discovery and the searches as well as trips
+	client := api.NewClient(cfg.GetAPIKey()).
+		WithRateLimiter(api.NewRateLimiter(connectTripsLimit))
+
+	vias, err := connectGateways(client, from, to, sources)
+	if err != nil {
+		return err
+	}
+	if len(vias) == 0 {
+		fmt.Println("No connecting airports found.")
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "Connecting via %s\n", strings.Join(vias, ", "))
+
+	first := api.SearchParams{
+		OriginAirports:      []string{from},
+		DestinationAirports: vias,
+		StartDate:           connectStartDate,
+		EndDate:             connectEndDate,
+		Cabin:               cabinCodeToName(cabin),
+		Sources:             sources,
+	}
+	second := first
+	second.OriginAirports, second.DestinationAirports = vias, []string{to}
+	// A first leg on the last day can land after local midnight, so the
+	// onward leg is searched a day further
+	if connectEndDate != "" {
+		end, err := time.Parse("2006-01-02", connectEndDate)
+		if err != nil {
+			return fmt.Errorf("invalid --end-date %q (use YYYY-MM-DD)", connectEndDate)
+		}
+		second.EndDate = end.AddDate(0, 0, 1).Format("2006-01-02")
+	}
+
+	inbound, err := connectLegTrips(client, first, cabin)
+	if err != nil {
+		return err
+	}
+	outbound, err := connectLegTrips(client, second, cabin)
+	if err != nil {
+		return err
+	}
+
+	rules := connect.Rules{MinConnection: connectMinConn, MaxConnection: connectMaxConn, NextDay: connectNextDay}
+	itineraries := connect.Pair(inbound, outbound, rules)
+	if err := rankItineraries(itineraries, connectRankBy, pointValues()); err != nil {
+		return err
+	}
+	if connectLimit > 0 && len(itineraries) > connectLimit {
+		itineraries = itineraries[:connectLimit]
+	}
+
+	switch strings.ToLower(connectOutput) {
+	case "json":
+		return export.WriteJSON(os.Stdout, itineraries, true)
+	default:
+		printItineraries(itineraries)
+	}
+
+	return nil
+}
+
+// connectGateways returns the --via airports, finding them from each
+// program's routes when set to auto
+func connectGateways(client *api.Client, from, to string, sources []string) ([]string, error) {
+	if !isAutoVia() {
+		var vias []string
+		for _, v := range parseCSV(connectVia) {
+			vias = append(vias, strings.ToUpper(v))
+		}
+		return vias, nil
+	}
+
+	gateways := connect.Gateways(programRoutes(client, sources), from, to, connectMaxDetour)
+	if connectMaxVia > 0 && len(gateways) > connectMaxVia {
+		gateways = gateways[:connectMaxVia]
+	}
+
+	vias := make([]string, len(gateways))
+	for i, g := range gateways {
+		vias[i] = g.Airport
+	}
+	return vias, nil
+}
+
+// isAutoVia reports whether gateways are found from routes
+func isAutoVia() bool {
+	return strings.EqualFold(strings.TrimSpace(connectVia), "auto")
+}
+
+// programRoutes fetches the routes of each program, one API call apiece. A
+// program that fails is skipped with a warning so it doesn't hide the routes
+// of the others.
+func programRoutes(client *api.Client, sources []string) []api.Route {
+	fmt.Fprintf(os.Stderr, "Loading the routes of %d programs...\n", len(sources))
+
+	var routes []api.Route
+	for _, source := range sources {
+		resp, err := client.GetRoutes(api.RoutesParams{Source: source})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping %s: %v\n", api.SourceDisplayName(source), err)
+			continue
+		}
+		routes = append(routes, resp.Data...)
+	}
+	return routes
+}
+
+// connectLegTrips searches one leg and fetches the trips of every result
+// with seats for the party, keeping those in the requested cabin
+func connectLegTrips(client *api.Client, params api.SearchParams, cabin string) ([]connect.Leg, error) {
+	results, err := client.SearchAll(params)
+	if err != nil {
+		return nil, fmt.Errorf("search %s to %s failed: %w",
+			strings.Join(params.OriginAirports, ","), strings.Join(params.DestinationAirports, ","), err)
+	}
+	results, _ = partyResults(results, connectPax, valueCabins(cabin))
+
+	var legs []connect.Leg
+	for _, item := range fetchAvailabilityTrips(client, results, connectTripsConc, filter.TripOptions{MaxStops: -1}, connectPax) {
+		for _, t := range item.Trips {
+			if cabin == "" || api.CabinCode(t.Cabin) == cabin {
+				legs = append(legs, connect.NewLeg(t, item.Route))
+			}
+		}
+	}
+	return legs, nil
+}
+
+// rankItineraries sorts itineraries in place by combined value, miles or
+// connection time. An empty key keeps the default miles order.
+func rankItineraries(items []connect.Itinerary, by string, vals valuation.Table) error {
+	var key func(it connect.Itinerary) float64
+	switch strings.ToLower(by) {
+	case "", "miles":
+		return nil
+	case "value":
+		key = func(it connect.Itinerary) float64 { return itineraryValue(it, vals) }
+	case "connection":
+		key = func(it connect.Itinerary) float64 { return float64(it.Connection) }
+	default:
+		return fmt.Errorf("invalid --rank-by %q (use value, miles or connection)", by)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool { return key(items[i]) < key(items[j]) })
+	return nil
+}
+
+func itineraryValue(it connect.Itinerary, vals valuation.Table) float64 {
+	return vals.Trip(it.First.Trip).Cost + vals.Trip(it.Second.Trip).Cost
+}
+
+func printItineraries(items []connect.Itinerary) {
+	if len(items) == 0 {
+		fmt.Println("No connecting itineraries found.")
+		return
+	}
+
+	fmt.Printf("Found %d connecting itineraries:\n\n", len(items))
+
+	vals := pointValues()
+	for i, it := range items {
+		fmt.Printf("%d. %s → %s → %s · %s miles · %s\n", i+1, it.First.Origin, it.Via, it.Second.Destination,
+			formatMilesK(it.Miles), valuation.Value{Cost: itineraryValue(it, vals)})
+
+		printItineraryLeg(it.First)
+		note := ""
+		if it.NextDay {
+			note = ", next day"
+		}
+		fmt.Printf("     connect %s at %s%s\n", formatDuration(time.Duration(it.Connection)*time.Minute), it.Via, note)
+		printItineraryLeg(it.Second)
+		fmt.Println()
+	}
+}
+
+func printItineraryLeg(leg connect.Leg) {
+	t, from, to := leg.Trip, leg.Origin, leg.Destination
+	departs, arrives := t.DepartsAt, t.ArrivesAt
+	if segs := t.AvailabilitySegments; len(segs) > 0 {
+		departs, arrives = segs[0].DepartsAt, segs[len(segs)-1].ArrivesAt
+	}
+	fmt.Printf("   %s %s %s → %s %s  %s · %s · %s miles\n",
+		airports.Local(from, departs).Format("2006-01-02"),
+		from, airports.Local(from, departs).Format("15:04"),
+		to, airports.Local(to, arrives).Format("15:04"),
+		t.FlightNumbers, api.SourceDisplayName(t.Source), formatMilesK(t.PartyMiles()))
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/daemon"
+	"github.com/JHill6253/seats-aero-cli/internal/history"
+	"github.com/JHill6253/seats-aero-cli/internal/notify"
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run saved searches on a schedule",
+	Long: `Run saved searches continuously according to their schedules.
+
+Each run is written to the history store (history/ next to config.yaml), and
+notifications are sent when a search finds new or cheaper availability
+compared with its previous run. API calls are throttled by a global rate
+limit and stop for the day once the daily quota is used.
+
+Schedules are set per saved search with a cron expression or an interval:
+  seats saved add tokyo-j --from SFO --to NRT --schedule "0 */6 * * *" --force
+  seats saved add tokyo-j --from SFO --to NRT --schedule "@every 2h" --force
+
+Examples:
+  seats daemon
+  seats daemon --run-now --health-addr :8787
+  seats daemon --rate-limit 5 --daily-quota 500`,
+	RunE: runDaemon,
+}
+
+var (
+	daemonRunNow     bool
+	daemonHealthAddr string
+	daemonRateLimit  int
+	daemonDailyQuota int
+)
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+
+	daemonCmd.Flags().BoolVar(&daemonRunNow, "run-now", false, "Run every scheduled search once at startup")
+	daemonCmd.Flags().StringVar(&daemonHealthAddr, "health-addr", "", "Address for the health endpoint (default from config, empty to disable)")
+	daemonCmd.Flags().IntVar(&daemonRateLimit, "rate-limit", 0, "Maximum API requests per minute (default from config)")
+	daemonCmd.Flags().IntVar(&daemonDailyQuota, "daily-quota", 0, "Maximum API requests per day (default from config)")
+}
+
+func runDaemon(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if cfg == nil {
+		return fmt.Errorf("configuration not loaded")
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	store, err := loadSavedStore()
+	if err != nil {
+		return err
+	}
+
+	historyDir, err := history.DefaultDir()
+	if err != nil {
+		return err
+	}
+
+	rateLimit := cfg.Daemon.RateLimit
+	if cmd.Flags().Changed("rate-limit") {
+		rateLimit = daemonRateLimit
+	}
+	dailyQuota := cfg.Daemon.DailyQuota
+	if cmd.Flags().Changed("daily-quota") {
+		dailyQuota = daemonDailyQuota
+	}
+	healthAddr := cfg.Daemon.HealthAddr
+	if cmd.Flags().Changed("health-addr") {
+		healthAddr = daemonHealthAddr
+	}
+
+	quota := api.NewQuota(dailyQuota)
+	client := api.NewClient(cfg.GetAPIKey()).
+		WithRateLimiter(api.NewRateLimiter(rateLimit)).
+		WithQuota(quota)
+
+	logger := log.New(os.Stderr, "seats: ", log.LstdFlags)
+
+	d, err := daemon.New(daemon.Options{
+		Client:    client,
+		Quota:     quota,
+		History:   history.NewStore(historyDir).WithRetention(cfg.Daemon.HistoryKeep),
+		Searches:  store.Searches,
+		QuotaFile: filepath.Join(historyDir, "quota.json"),
+		Notifiers: func(names []string) ([]notify.Notifier, error) {
+			return selectNotifiers(cfg.Notifications, names)
+		},
+		Logger: logger,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var server *http.Server
+	if healthAddr != "" {
+		server = &http.Server{Addr: healthAddr, Handler: d.Handler()}
+		go func() {
+			logger.Printf("health endpoint listening on http://%s/healthz", healthAddr)
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Printf("health endpoint failed: %v", err)
+			}
+		}()
+	}
+
+	err = d.Run(ctx, daemonRunNow)
+
+	if server != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Printf("health endpoint shutdown: %v", err)
+		}
+	}
+
+	return err
+}
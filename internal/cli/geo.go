@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/JHill6253/seats-aero-cli/internal/export"
+)
+
+// writeMap writes map lines as GeoJSON or KML, warning about airports that
+// have no bundled coordinates and so were left off the map
+func writeMap(output, name string, lines []export.MapLine, missing []string) error {
+	if len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "Skipped routes with %d airports outside the bundled list: %s\n", len(missing), strings.Join(missing, ", "))
+	}
+
+	if strings.ToLower(output) == "kml" {
+		return export.LinesToKML(os.Stdout, name, lines)
+	}
+	return export.LinesToGeoJSON(os.Stdout, lines)
+}
+
+// mapName titles a KML document from the command's filters
+func mapName(command string, parts ...string) string {
+	var filters []string
+	for _, p := range parts {
+		if p != "" {
+			filters = append(filters, p)
+		}
+	}
+	if len(filters) == 0 {
+		return "seats " + command
+	}
+	return "seats " + command + ": " + strings.Join(filters, " ")
+}
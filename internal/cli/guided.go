@@ -11,6 +11,7 @@ import (
 	"github.com/JHill6253/seats-aero-cli/internal/api"
 	"github.com/JHill6253/seats-aero-cli/internal/config"
 	"github.com/JHill6253/seats-aero-cli/internal/export"
+	"github.com/JHill6253/seats-aero-cli/internal/saved"
 )
 
 var (
@@ -31,9 +32,20 @@ const (
 	ActionAvailability Action = "availability"
 	ActionRoutes       Action = "routes"
 	ActionTrips        Action = "trips"
+	ActionSaved        Action = "saved"
 	ActionExit         Action = "exit"
 )
 
+// ResultAction represents what to do next with a set of results
+type ResultAction string
+
+const (
+	ResultTrips  ResultAction = "trips"
+	ResultExport ResultAction = "export"
+	ResultShow   ResultAction = "show"
+	ResultDone   ResultAction = "done"
+)
+
 // ExportFormat represents export options
 type ExportFormat string
 
@@ -41,6 +53,10 @@ const (
 	ExportNone ExportFormat = "none"
 	ExportJSON ExportFormat = "json"
 	ExportCSV  ExportFormat = "csv"
+	ExportICS  ExportFormat = "ics"
+	ExportMD   ExportFormat = "md"
+	ExportHTML ExportFormat = "html"
+	ExportXLSX ExportFormat = "xlsx"
 )
 
 // RunGuided runs the interactive guided CLI
@@ -70,6 +86,7 @@ func RunGuided(cfg *config.Config) error {
 				huh.NewOption("View bulk availability", ActionAvailability),
 				huh.NewOption("List routes", ActionRoutes),
 				huh.NewOption("Get trip details", ActionTrips),
+				huh.NewOption("Run a saved search", ActionSaved),
 				huh.NewOption("Exit", ActionExit),
 			).
 			Value(&action).
@@ -99,6 +116,10 @@ func RunGuided(cfg *config.Config) error {
 			if err := runGuidedTrips(cfg); err != nil {
 				fmt.Printf("Error: %v\n\n", err)
 			}
+		case ActionSaved:
+			if err := runGuidedSaved(cfg); err != nil {
+				fmt.Printf("Error: %v\n\n", err)
+			}
 		case ActionExit:
 			fmt.Println("Goodbye!")
 			return nil
@@ -114,6 +135,7 @@ func runGuidedSearch(cfg *config.Config) error {
 		endDate     string
 		cabin       string
 		source      string
+		passengers  = "1"
 	)
 
 	// Pre-fill from config
@@ -179,6 +201,8 @@ func runGuidedSearch(cfg *config.Config) error {
 				Title("Mileage program (optional)").
 				Description("e.g., aeroplan, united, alaska").
 				Value(&source),
+
+			passengersInput(&passengers),
 		),
 	)
 
@@ -208,15 +232,24 @@ func runGuidedSearch(cfg *config.Config) error {
 		return fmt.Errorf("search failed: %w", err)
 	}
 
-	fmt.Println()
-	printSearchResults(resp.Data)
+	pax, _ := parsePassengers(passengers)
+	return showGuidedResults(client, resp.Data, valueCabins(cabin), pax)
+}
+
+// showGuidedResults narrows results to the party, browses them and then
+// offers follow-up actions
+func showGuidedResults(client *api.Client, data []api.Availability, cabins []string, passengers int) error {
+	results, combos := partyResults(data, passengers, cabins)
+	printPartySuggestions(os.Stdout, combos, passengers)
+
+	if err := browseResults(client, results, passengers); err != nil {
+		return err
+	}
 	fmt.Println()
 
-	// Export prompt
-	if len(resp.Data) > 0 {
-		if err := promptExport(resp.Data); err != nil {
-			return err
-		}
+	// Drill down into trips or export without re-querying
+	if len(results) > 0 {
+		return promptResultActions(client, results, passengers)
 	}
 
 	return nil
@@ -224,8 +257,9 @@ func runGuidedSearch(cfg *config.Config) error {
 
 func runGuidedAvailability(cfg *config.Config) error {
 	var (
-		source string
-		cabin  string
+		source     string
+		cabin      string
+		passengers = "1"
 	)
 
 	// Build source options
@@ -259,6 +293,8 @@ func runGuidedAvailability(cfg *config.Config) error {
 					huh.NewOption("First", "first"),
 				).
 				Value(&cabin),
+
+			passengersInput(&passengers),
 		),
 	)
 
@@ -283,17 +319,8 @@ func runGuidedAvailability(cfg *config.Config) error {
 		return fmt.Errorf("get availability failed: %w", err)
 	}
 
-	fmt.Println()
-	printAvailabilityResults(resp.Data)
-	fmt.Println()
-
-	if len(resp.Data) > 0 {
-		if err := promptExport(resp.Data); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	pax, _ := parsePassengers(passengers)
+	return showGuidedResults(client, resp.Data, valueCabins(cabin), pax)
 }
 
 func runGuidedRoutes(cfg *config.Config) error {
@@ -353,7 +380,10 @@ func runGuidedRoutes(cfg *config.Config) error {
 }
 
 func runGuidedTrips(cfg *config.Config) error {
-	var availabilityID string
+	var (
+		availabilityID string
+		passengers     = "1"
+	)
 
 	form := huh.NewForm(
 		huh.NewGroup(
@@ -367,6 +397,8 @@ func runGuidedTrips(cfg *config.Config) error {
 					}
 					return nil
 				}),
+
+			passengersInput(&passengers),
 		),
 	)
 
@@ -386,35 +418,285 @@ func runGuidedTrips(cfg *config.Config) error {
 		return fmt.Errorf("get trips failed: %w", err)
 	}
 
+	pax, _ := parsePassengers(passengers)
+	fmt.Println()
+	printTripsResults(partyTrips(resp.Data, pax))
+	fmt.Println()
+
+	return nil
+}
+
+// passengersInput is the party-size field shared by the guided forms
+func passengersInput(value *string) *huh.Input {
+	return huh.NewInput().
+		Title("Passengers").
+		Description("Only show options with seats for everyone").
+		Value(value).
+		Validate(func(s string) error {
+			_, err := parsePassengers(s)
+			return err
+		})
+}
+
+func runGuidedSaved(cfg *config.Config) error {
+	store, err := loadSavedStore()
+	if err != nil {
+		return err
+	}
+
+	if len(store.Searches) == 0 {
+		fmt.Println("\nNo saved searches. Create one with: seats saved add <name> --from SFO --to NRT")
+		fmt.Println()
+		return nil
+	}
+
+	// An empty name selects every saved search
+	options := []huh.Option[string]{
+		huh.NewOption("All saved searches", ""),
+	}
+	for _, s := range store.Searches {
+		options = append(options, huh.NewOption(fmt.Sprintf("%s (%s)", s.Name, s.Describe()), s.Name))
+	}
+
+	var name string
+	err = huh.NewSelect[string]().
+		Title("Saved search").
+		Options(options...).
+		Value(&name).
+		Run()
+
+	if err != nil {
+		if err == huh.ErrUserAborted {
+			return nil
+		}
+		return err
+	}
+
+	searches := store.Searches
+	if name != "" {
+		search, _ := store.Get(name)
+		searches = []saved.Search{search}
+	}
+
+	fmt.Println("\nSearching...")
+	fmt.Println()
+
+	results := runSavedSearches(cfg, searches, true)
+	printSavedReport(results)
+	fmt.Println()
+
+	var all []api.Availability
+	for _, r := range results {
+		all = append(all, r.Results...)
+	}
+	if len(all) > 0 {
+		return promptResultActions(api.NewClient(cfg.GetAPIKey()), all, 1)
+	}
+
+	return nil
+}
+
+// promptResultActions lets the user drill into trips, export, or review the
+// results again, all from the results already fetched
+func promptResultActions(client *api.Client, results []api.Availability, passengers int) error {
+	for {
+		var action ResultAction
+
+		err := huh.NewSelect[ResultAction]().
+			Title("What next?").
+			Options(
+				huh.NewOption("View trip details for a result", ResultTrips),
+				huh.NewOption("Export results", ResultExport),
+				huh.NewOption("Show results again", ResultShow),
+				huh.NewOption("Back to main menu", ResultDone),
+			).
+			Value(&action).
+			Run()
+
+		if err != nil {
+			if err == huh.ErrUserAborted {
+				return nil
+			}
+			return err
+		}
+
+		switch action {
+		case ResultTrips:
+			if err := promptTripDrillDown(client, results, passengers); err != nil {
+				fmt.Printf("Error: %v\n\n", err)
+			}
+		case ResultExport:
+			if err := promptExport(results); err != nil {
+				return err
+			}
+		case ResultShow:
+			if err := browseResults(client, results, passengers); err != nil {
+				return err
+			}
+			fmt.Println()
+		case ResultDone:
+			return nil
+		}
+	}
+}
+
+// promptTripDrillDown picks a result row, fetches its trips and offers to export them
+func promptTripDrillDown(client *api.Client, results []api.Availability, passengers int) error {
+	options := make([]huh.Option[int], 0, len(results))
+	for i, a := range results {
+		options = append(options, huh.NewOption(resultLabel(a), i))
+	}
+
+	var selected int
+	err := huh.NewSelect[int]().
+		Title("Select a result").
+		Description("Type / to filter").
+		Options(options...).
+		Height(15).
+		Value(&selected).
+		Run()
+
+	if err != nil {
+		if err == huh.ErrUserAborted {
+			return nil
+		}
+		return err
+	}
+
+	a := results[selected]
+	fmt.Println("\nFetching trip details...")
+
+	resp, err := client.GetTrips(a.ID)
+	if err != nil {
+		return fmt.Errorf("get trips failed: %w", err)
+	}
+
+	trips := partyTrips(resp.Data, passengers)
 	fmt.Println()
-	printTripsResults(resp.Data)
+	printTripsResults(trips)
 	fmt.Println()
 
+	if len(trips) > 0 {
+		return promptTripsExport(a, trips)
+	}
+	return nil
+}
+
+// resultLabel summarizes an availability row for a select option
+func resultLabel(a api.Availability) string {
+	var cabins []string
+	for _, cabin := range api.ValidCabins() {
+		if c := a.Cabin(cabin); c.Available {
+			cabins = append(cabins, fmt.Sprintf("%s %s", cabin, formatCabinInfo(true, c.MileageCost, c.RemainingSeats)))
+		}
+	}
+	return fmt.Sprintf("%s  %s → %s  %-22s %s",
+		a.Date,
+		a.Route.OriginAirport,
+		a.Route.DestinationAirport,
+		api.SourceDisplayName(a.Source),
+		strings.Join(cabins, "  "),
+	)
+}
+
+func promptTripsExport(a api.Availability, trips []api.Trip) error {
+	format, filename, err := promptExportTarget(huh.NewOption("Calendar (ICS)", ExportICS))
+	if err != nil || format == ExportNone {
+		return err
+	}
+
+	filename = withExtension(filename, format)
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	switch format {
+	case ExportJSON:
+		err = export.TripsToJSON(f, trips, true)
+	case ExportCSV:
+		err = export.TripsToCSV(f, trips, pointValues())
+	case ExportMD:
+		err = export.TripsToMarkdown(f, trips, tripsSummary(trips), pointValues())
+	case ExportHTML:
+		err = export.TripsToHTML(f, trips, tripsSummary(trips), pointValues())
+	case ExportXLSX:
+		err = export.ToXLSX(f, export.Workbook{Trips: trips}, pointValues())
+	case ExportICS:
+		err = writeICS(f, export.Workbook{Availability: []api.Availability{a}, Trips: trips})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export %s: %w", strings.ToUpper(string(format)), err)
+	}
+
+	fmt.Printf("Exported to %s\n\n", filename)
 	return nil
 }
 
 func promptExport(data []api.Availability) error {
+	format, filename, err := promptExportTarget()
+	if err != nil || format == ExportNone {
+		return err
+	}
+
+	filename = withExtension(filename, format)
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	switch format {
+	case ExportJSON:
+		err = export.ToJSON(f, data, true)
+	case ExportCSV:
+		err = export.ToCSV(f, data, pointValues())
+	case ExportMD:
+		err = export.ToMarkdown(f, data, resultsSummary(data), pointValues())
+	case ExportHTML:
+		err = export.ToHTML(f, data, resultsSummary(data), pointValues())
+	case ExportXLSX:
+		err = export.ToXLSX(f, export.Workbook{Availability: data}, pointValues())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export %s: %w", strings.ToUpper(string(format)), err)
+	}
+
+	fmt.Printf("Exported to %s\n\n", filename)
+	return nil
+}
+
+// promptExportTarget asks for an export format and filename, offering any
+// extra formats after the common ones. It returns ExportNone if the user
+// declines or aborts.
+func promptExportTarget(extra ...huh.Option[ExportFormat]) (ExportFormat, string, error) {
 	var format ExportFormat
 
+	options := append([]huh.Option[ExportFormat]{
+		huh.NewOption("No", ExportNone),
+		huh.NewOption("JSON", ExportJSON),
+		huh.NewOption("CSV", ExportCSV),
+		huh.NewOption("Markdown report", ExportMD),
+		huh.NewOption("HTML report", ExportHTML),
+		huh.NewOption("Excel workbook", ExportXLSX),
+	}, extra...)
+
 	err := huh.NewSelect[ExportFormat]().
 		Title("Export results?").
-		Options(
-			huh.NewOption("No", ExportNone),
-			huh.NewOption("JSON", ExportJSON),
-			huh.NewOption("CSV", ExportCSV),
-		).
+		Options(options...).
 		Value(&format).
 		Run()
 
 	if err != nil {
 		if err == huh.ErrUserAborted {
-			return nil
+			return ExportNone, "", nil
 		}
-		return err
+		return ExportNone, "", err
 	}
 
 	if format == ExportNone {
-		return nil
+		return ExportNone, "", nil
 	}
 
 	var filename string
@@ -431,42 +713,21 @@ func promptExport(data []api.Availability) error {
 
 	if err != nil {
 		if err == huh.ErrUserAborted {
-			return nil
+			return ExportNone, "", nil
 		}
-		return err
+		return ExportNone, "", err
 	}
 
-	// Add extension if not present
-	filename = strings.TrimSpace(filename)
-	switch format {
-	case ExportJSON:
-		if !strings.HasSuffix(filename, ".json") {
-			filename += ".json"
-		}
-		f, err := os.Create(filename)
-		if err != nil {
-			return fmt.Errorf("failed to create file: %w", err)
-		}
-		defer f.Close()
-		if err := export.ToJSON(f, data, true); err != nil {
-			return fmt.Errorf("failed to export JSON: %w", err)
-		}
-	case ExportCSV:
-		if !strings.HasSuffix(filename, ".csv") {
-			filename += ".csv"
-		}
-		f, err := os.Create(filename)
-		if err != nil {
-			return fmt.Errorf("failed to create file: %w", err)
-		}
-		defer f.Close()
-		if err := export.ToCSV(f, data); err != nil {
-			return fmt.Errorf("failed to export CSV: %w", err)
-		}
-	}
+	return format, strings.TrimSpace(filename), nil
+}
 
-	fmt.Printf("Exported to %s\n\n", filename)
-	return nil
+// withExtension adds the format's file extension if not present
+func withExtension(filename string, format ExportFormat) string {
+	ext := "." + string(format)
+	if !strings.HasSuffix(filename, ext) {
+		filename += ext
+	}
+	return filename
 }
 
 func parseCSVLower(s string) []string {
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/mcp"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Run a Model Context Protocol server over stdio",
+	Long: `Run a Model Context Protocol (MCP) server on stdin/stdout so assistants can
+query award space through the seats client.
+
+Exposed tools: search, bulk_availability, routes and trip_details. Results
+are returned as compact JSON to conserve tokens.
+
+Example client configuration:
+  {
+    "mcpServers": {
+      "seats": { "command": "seats", "args": ["mcp"] }
+    }
+  }`,
+	Args: cobra.NoArgs,
+	RunE: runMCP,
+}
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+}
+
+func runMCP(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if cfg == nil {
+		return fmt.Errorf("configuration not loaded")
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg.GetAPIKey())
+	version := rootCmd.Version
+	if version == "" {
+		version = "dev"
+	}
+	server := mcp.NewServer(client, version)
+
+	return server.Serve(os.Stdin, os.Stdout)
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/export"
+	"github.com/JHill6253/seats-aero-cli/internal/filter"
+	"github.com/JHill6253/seats-aero-cli/internal/party"
+)
+
+// isNDJSON reports whether an --output value selects newline-delimited JSON
+func isNDJSON(output string) bool {
+	return strings.ToLower(output) == "ndjson"
+}
+
+// newNDJSONOutput starts NDJSON output on stdout, writing an envelope line
+// describing the query first when requested
+func newNDJSONOutput(envelope bool, command string, query interface{}) (*export.NDJSONWriter, error) {
+	out := export.NewNDJSONWriter(os.Stdout)
+	if envelope {
+		if err := out.Envelope(command, query); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// writeNDJSON writes already-fetched records as NDJSON
+func writeNDJSON[T any](envelope bool, command string, query interface{}, records []T) error {
+	out, err := newNDJSONOutput(envelope, command, query)
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		if err := out.Write(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// availabilityStream configures streaming paginated availability as NDJSON
+type availabilityStream struct {
+	client      *api.Client
+	passengers  int
+	cabins      []string
+	withTrips   bool
+	concurrency int
+	seen        func(page []api.Availability) // optional, called with each narrowed page
+}
+
+// write is a page callback for the paginated fetchers. Each page is narrowed
+// to the party and written before the next page is requested, so memory stays
+// bounded by the page size. With trips, each line nests its trips.
+func (s availabilityStream) write(out *export.NDJSONWriter) func(page []api.Availability) error {
+	return func(page []api.Availability) error {
+		if s.passengers > 1 {
+			page = party.Scale(party.Availability(page, s.passengers, s.cabins), s.passengers)
+		}
+		if s.seen != nil {
+			s.seen(page)
+		}
+
+		if s.withTrips {
+			items := fetchAvailabilityTrips(s.client, page, s.concurrency, filter.TripOptions{MaxStops: -1}, s.passengers)
+			for _, item := range items {
+				if err := out.Write(item); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		for _, a := range page {
+			if err := out.Write(a); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// checkStreamRanking rejects ranking for streamed output, which is written
+// in API order before later pages are known
+func checkStreamRanking(rankBy string) error {
+	if rankBy != "" {
+		return fmt.Errorf("--rank-by is not supported with --output ndjson, which streams results in API order")
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/JHill6253/seats-aero-cli/internal/airports"
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/export"
+	"github.com/JHill6253/seats-aero-cli/internal/network"
+)
+
+var (
+	sectionStyle = lipgloss.NewStyle().Bold(true)
+
+	mapStyles = map[network.CellKind]lipgloss.Style{
+		network.Land:        lipgloss.NewStyle().Foreground(lipgloss.Color("#4B5563")),
+		network.Path:        lipgloss.NewStyle().Foreground(lipgloss.Color("#0891B2")),
+		network.Destination: lipgloss.NewStyle().Foreground(lipgloss.Color("#16A34A")).Bold(true),
+		network.Origin:      lipgloss.NewStyle().Foreground(lipgloss.Color("#DC2626")).Bold(true),
+		network.Label:       lipgloss.NewStyle().Bold(true),
+	}
+)
+
+// showRoutesNetwork prints the --map plot and --stats summary for routes
+func showRoutesNetwork(routes []api.Route) error {
+	output := strings.ToLower(routesOutput)
+	switch {
+	case output == "json" && routesStats && !routesMap:
+		return export.WriteJSON(os.Stdout, network.Summarize(routes, routesTop), true)
+	case output != "table":
+		return fmt.Errorf("--stats and --map support table output, and --stats also json")
+	}
+
+	if len(routes) == 0 {
+		fmt.Println("No routes found.")
+		return nil
+	}
+
+	if routesMap {
+		printRouteMap(routes)
+	}
+	if routesStats {
+		printRouteStats(network.Summarize(routes, routesTop))
+	}
+	return nil
+}
+
+func printRouteMap(routes []api.Route) {
+	center := 0.0
+	if a, ok := airports.Lookup(routesOrigin); ok {
+		center = a.Lon
+	}
+
+	plot, missing := network.PlotRoutes(routes, routesMapWidth, center)
+
+	fmt.Println(titleStyle.Render(fmt.Sprintf("Routes from %s · %s", strings.ToUpper(routesOrigin), api.SourceDisplayName(routesSource))))
+	for _, row := range plot.Rows {
+		var b strings.Builder
+		for _, c := range row {
+			if style, ok := mapStyles[c.Kind]; ok {
+				b.WriteString(style.Render(string(c.Char)))
+			} else {
+				b.WriteRune(c.Char)
+			}
+		}
+		fmt.Println(b.String())
+	}
+	if len(missing) > 0 {
+		fmt.Println(subtitleStyle.Render("Not plotted (no coordinates): " + strings.Join(missing, ", ")))
+	}
+	fmt.Println()
+}
+
+func printRouteStats(stats network.Stats) {
+	programs := make([]string, len(stats.Sources))
+	for i, s := range stats.Sources {
+		programs[i] = api.SourceDisplayName(s)
+	}
+
+	fmt.Println(titleStyle.Render("Network: " + strings.Join(programs, ", ")))
+	// Countries only count bundled airports, so say how many are left out
+	countries := fmt.Sprintf("%d countries", len(stats.Countries))
+	if len(stats.Unknown) > 0 {
+		countries += fmt.Sprintf(" (%d airports unknown)", len(stats.Unknown))
+	}
+	fmt.Println(subtitleStyle.Render(fmt.Sprintf("%d routes · %d airports · %s · average %d mi · median %d mi",
+		stats.Routes, stats.Airports, countries, stats.AverageDistance, stats.MedianDistance)))
+	fmt.Println()
+
+	fmt.Println(sectionStyle.Render("Hubs by degree"))
+	fmt.Printf("%-5s %-20s %-7s %6s %4s %4s\n", "Code", "City", "Country", "Degree", "Out", "In")
+	fmt.Println(strings.Repeat("-", 51))
+	for _, h := range stats.Hubs {
+		fmt.Printf("%-5s %-20.20s %-7s %6d %4d %4d\n", h.Airport, h.City, h.Country, h.Degree, h.Outbound, h.Inbound)
+	}
+	fmt.Println()
+
+	fmt.Println(sectionStyle.Render("Countries served (routes touching each)"))
+	served := make([]string, 0, len(stats.Countries))
+	for _, c := range stats.Countries {
+		served = append(served, fmt.Sprintf("%s (%d)", c.Country, c.Routes))
+	}
+	fmt.Println(wrapList(served, 78))
+	fmt.Println()
+
+	fmt.Println(sectionStyle.Render("Distance distribution"))
+	most := 0
+	for _, b := range stats.Distances {
+		if b.Count > most {
+			most = b.Count
+		}
+	}
+	for _, b := range stats.Distances {
+		bar := 0
+		if most > 0 {
+			bar = b.Count * 40 / most
+		}
+		if b.Count > 0 && bar == 0 {
+			bar = 1
+		}
+		fmt.Printf("%-15s %5d %s\n", b.Label, b.Count, strings.Repeat("#", bar))
+	}
+	fmt.Println()
+
+	printRouteList("Longest routes", stats.Longest)
+	printRouteList("Shortest routes", stats.Shortest)
+
+	if len(stats.Unknown) > 0 {
+		fmt.Println(subtitleStyle.Render("No bundled data for: " + strings.Join(stats.Unknown, ", ")))
+	}
+}
+
+func printRouteList(title string, routes []api.Route) {
+	fmt.Println(sectionStyle.Render(title))
+	for _, r := range routes {
+		fmt.Printf("%-5s %-5s %8d mi  %s\n", r.OriginAirport, r.DestinationAirport, network.RouteDistance(r), api.SourceDisplayName(r.Source))
+	}
+	fmt.Println()
+}
+
+// wrapList joins items with commas, breaking lines before width
+func wrapList(items []string, width int) string {
+	var b strings.Builder
+	line := 0
+	for i, item := range items {
+		if i > 0 {
+			b.WriteString(",")
+			line++
+			if line+len(item)+1 > width {
+				b.WriteString("\n")
+				line = 0
+			} else {
+				b.WriteString(" ")
+				line++
+			}
+		}
+		b.WriteString(item)
+		line += len(item)
+	}
+	return b.String()
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/JHill6253/seats-aero-cli/internal/config"
+	"github.com/JHill6253/seats-aero-cli/internal/notify"
+)
+
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Manage notification sinks",
+	Long: `List and test the notification sinks configured in config.yaml.
+
+Examples:
+  seats notify list
+  seats notify test
+  seats notify test team-slack`,
+}
+
+var notifyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured notification sinks",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := GetConfig()
+		if cfg == nil {
+			return fmt.Errorf("configuration not loaded")
+		}
+
+		if len(cfg.Notifications) == 0 {
+			fmt.Println("No notification sinks configured.")
+			return nil
+		}
+
+		fmt.Printf("%-20s %-10s %s\n", "Name", "Type", "Target")
+		for _, n := range cfg.Notifications {
+			fmt.Printf("%-20s %-10s %s\n", n.Name, n.Type, notifierTarget(n))
+		}
+		return nil
+	},
+}
+
+var notifyTestCmd = &cobra.Command{
+	Use:   "test [name]",
+	Short: "Send a test message to notification sinks",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := GetConfig()
+		if cfg == nil {
+			return fmt.Errorf("configuration not loaded")
+		}
+
+		notifiers, err := selectNotifiers(cfg.Notifications, args)
+		if err != nil {
+			return err
+		}
+		if len(notifiers) == 0 {
+			return fmt.Errorf("no notification sinks configured")
+		}
+
+		msg := notify.Message{
+			Title: "seats test notification",
+			Body:  "This is a test message from the seats CLI.",
+			Time:  time.Now(),
+		}
+		if err := notify.Dispatch(notifiers, msg); err != nil {
+			return fmt.Errorf("notification failed: %w", err)
+		}
+
+		fmt.Printf("Sent test message to %d sink(s)\n", len(notifiers))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(notifyCmd)
+	notifyCmd.AddCommand(notifyListCmd)
+	notifyCmd.AddCommand(notifyTestCmd)
+}
+
+// selectNotifiers builds the named notifiers, or all of them if names is empty
+func selectNotifiers(cfgs []config.NotifierConfig, names []string) ([]notify.Notifier, error) {
+	if len(names) == 0 {
+		return notify.FromConfig(cfgs)
+	}
+
+	var selected []config.NotifierConfig
+	for _, name := range names {
+		found := false
+		for _, c := range cfgs {
+			if c.Name == name {
+				selected = append(selected, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("notification sink %q not found", name)
+		}
+	}
+	return notify.FromConfig(selected)
+}
+
+func notifierTarget(n config.NotifierConfig) string {
+	switch n.Type {
+	case "email":
+		return fmt.Sprintf("%v via %s", n.To, n.SMTPHost)
+	case "command":
+		return n.Command
+	default:
+		return n.URL
+	}
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/party"
+)
+
+// maxPartySuggestions caps the split combinations shown when nothing fits
+const maxPartySuggestions = 5
+
+// partyResults keeps the results with seats for every passenger and prices
+// them for the whole party. When nothing fits it returns
+// split-cabin or split-program combinations instead.
+func partyResults(results []api.Availability, passengers int, cabins []string) ([]api.Availability, []party.Combo) {
+	if passengers <= 1 {
+		return results, nil
+	}
+
+	fit := party.Availability(results, passengers, cabins)
+	if len(fit) == 0 {
+		return []api.Availability{}, party.Suggest(results, passengers, cabins, maxPartySuggestions)
+	}
+	return party.Scale(fit, passengers), nil
+}
+
+// partyTrips keeps the trips with seats for every passenger, priced for the party
+func partyTrips(trips []api.Trip, passengers int) []api.Trip {
+	return party.ScaleTrips(party.Trips(trips, passengers), passengers)
+}
+
+func validatePassengers(n int) error {
+	if n < 1 {
+		return fmt.Errorf("--passengers must be at least 1")
+	}
+	return nil
+}
+
+// printPartySuggestions lists ways to seat the party across cabins or programs
+func printPartySuggestions(w io.Writer, combos []party.Combo, passengers int) {
+	if len(combos) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "No single option has %d seats. Split bookings that seat everyone:\n\n", passengers)
+	for _, c := range combos {
+		var kind []string
+		if c.SplitCabin {
+			kind = append(kind, "split cabin")
+		}
+		if c.SplitProgram {
+			kind = append(kind, "split program")
+		}
+		fmt.Fprintf(w, "%s %s · %s miles total (%s)\n", c.Date, c.Route, formatMilesK(c.TotalMiles), strings.Join(kind, ", "))
+		for _, p := range c.Parts {
+			fmt.Fprintf(w, "  %d × %s %s at %s  [%s]\n",
+				p.Seats, api.SourceDisplayName(p.Source), api.CabinDisplayName(p.Cabin), formatMilesK(p.Miles), p.ID)
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+// parsePassengers parses a passenger count entered in a guided form
+func parsePassengers(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 1, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("enter a number of passengers, 1 or more")
+	}
+	return n, nil
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/config"
+	"github.com/JHill6253/seats-aero-cli/internal/connect"
+)
+
+// positioningPlan is the alternate departures added by search --positioning,
+// with any award space found for the positioning legs
+type positioningPlan struct {
+	homes      []string
+	alternates []connect.Alternate
+	awards     map[string][]api.Availability // alternate airport -> awards from home
+	searched   bool                          // awards were searched
+}
+
+// planPositioning finds alternate departure airports near the home bases that
+// have routes to the destinations and adds them to the search origins. Home
+// bases are the --from airports; the preferred airports from the config are
+// only used when --from is left out.
+func planPositioning(client *api.Client, cfg *config.Config, params *api.SearchParams) (positioningPlan, error) {
+	var plan positioningPlan
+	for _, code := range params.OriginAirports {
+		plan.homes = append(plan.homes, strings.ToUpper(code))
+	}
+	if len(plan.homes) == 0 {
+		for _, code := range cfg.PreferredAirports {
+			plan.homes = append(plan.homes, strings.ToUpper(strings.TrimSpace(code)))
+		}
+	}
+	if len(plan.homes) == 0 {
+		return plan, fmt.Errorf("--positioning needs --from or preferred_airports in the config")
+	}
+
+	routes := programRoutes(client, params.Sources)
+	plan.alternates = connect.Alternates(routes, plan.homes, params.DestinationAirports, searchPosRadius)
+	if searchPosMax > 0 && len(plan.alternates) > searchPosMax {
+		plan.alternates = plan.alternates[:searchPosMax]
+	}
+
+	params.OriginAirports = append([]string(nil), plan.homes...)
+	for _, alt := range plan.alternates {
+		params.OriginAirports = append(params.OriginAirports, alt.Airport)
+	}
+
+	if searchPosAwards && len(plan.alternates) > 0 {
+		if err := plan.searchAwards(client, *params); err != nil {
+			return plan, err
+		}
+	}
+	return plan, nil
+}
+
+// searchAwards looks for award space from home to each alternate, starting
+// the day before the search so there's time to position
+func (p *positioningPlan) searchAwards(client *api.Client, params api.SearchParams) error {
+	legs := api.SearchParams{
+		OriginAirports: p.homes,
+		StartDate:      params.StartDate,
+		EndDate:        params.EndDate,
+	}
+	for _, alt := range p.alternates {
+		legs.DestinationAirports = append(legs.DestinationAirports, alt.Airport)
+	}
+	if start, err := time.Parse("2006-01-02", params.StartDate); err == nil {
+		legs.StartDate = start.AddDate(0, 0, -1).Format("2006-01-02")
+	}
+
+	results, err := client.SearchAll(legs)
+	if err != nil {
+		return fmt.Errorf("positioning search failed: %w", err)
+	}
+
+	p.searched = true
+	p.awards = map[string][]api.Availability{}
+	for _, a := range results {
+		dest := strings.ToUpper(a.Route.DestinationAirport)
+		p.awards[dest] = append(p.awards[dest], a)
+	}
+	return nil
+}
+
+// print notes the positioning leg needed for each alternate departure and,
+// when searched, the award space for it. Results are nil when they were
+// streamed rather than kept.
+func (p positioningPlan) print(w io.Writer, results []api.Availability) {
+	if len(p.homes) == 0 {
+		return
+	}
+	if len(p.alternates) == 0 {
+		fmt.Fprintf(w, "No alternate departures found near %s.\n", strings.Join(p.homes, ", "))
+		return
+	}
+
+	dates := map[string]map[string]bool{} // origin -> result dates
+	counts := map[string]int{}
+	for _, a := range results {
+		origin := strings.ToUpper(a.Route.OriginAirport)
+		counts[origin]++
+		if dates[origin] == nil {
+			dates[origin] = map[string]bool{}
+		}
+		dates[origin][a.Date] = true
+	}
+
+	fmt.Fprintf(w, "\nPositioning from %s:\n", strings.Join(p.homes, ", "))
+	for _, alt := range p.alternates {
+		var days []string
+		for d := range dates[alt.Airport] {
+			days = append(days, d)
+		}
+		sort.Strings(days)
+
+		note := fmt.Sprintf("  %-4s needs %s→%s (%d mi)", alt.Airport, alt.Home, alt.Airport, alt.Distance)
+		if results != nil {
+			if counts[alt.Airport] == 1 {
+				note += " · 1 result"
+			} else {
+				note += fmt.Sprintf(" · %d results", counts[alt.Airport])
+			}
+		}
+		if p.searched {
+			covered, lowest := connect.Coverage(days, p.awards[alt.Airport])
+			switch {
+			case len(days) == 0:
+			case covered == 0:
+				note += " · no award space, book cash"
+			default:
+				note += fmt.Sprintf(" · awards from %s miles for %d of %d dates", formatMilesK(lowest), covered, len(days))
+			}
+		}
+		fmt.Fprintln(w, note)
+	}
+}
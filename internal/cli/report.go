@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/export"
+)
+
+// searchSummary describes the search flags for a report header
+func searchSummary() export.Summary {
+	s := export.Summary{Title: fmt.Sprintf("Award search %s → %s", strings.ToUpper(searchFrom), strings.ToUpper(searchTo))}
+	s.Add("From", strings.ToUpper(searchFrom))
+	s.Add("To", strings.ToUpper(searchTo))
+	s.Add("Dates", dateRange(searchStartDate, searchEndDate))
+	if searchCabin != "" {
+		s.Add("Cabin", api.CabinDisplayName(api.CabinCode(searchCabin)))
+	}
+	s.Add("Programs", searchSource)
+	if searchDirect {
+		s.Add("Direct only", "yes")
+	}
+	if searchPax > 1 {
+		s.Add("Passengers", strconv.Itoa(searchPax))
+	}
+	return s
+}
+
+// availabilitySummary describes the availability flags for a report header
+func availabilitySummary() export.Summary {
+	s := export.Summary{Title: "Bulk availability · " + api.SourceDisplayName(strings.ToLower(availSource))}
+	s.Add("Cabin", strings.ToUpper(availCabin))
+	s.Add("Origin region", availOriginRegion)
+	s.Add("Destination region", availDestRegion)
+	s.Add("Dates", dateRange(availStartDate, availEndDate))
+	if availPax > 1 {
+		s.Add("Passengers", strconv.Itoa(availPax))
+	}
+	return s
+}
+
+// routesSummary describes the routes flags for a report header
+func routesSummary() export.Summary {
+	s := export.Summary{Title: "Routes"}
+	if routesSource != "" {
+		s.Add("Program", api.SourceDisplayName(strings.ToLower(routesSource)))
+	}
+	s.Add("Origin", strings.ToUpper(routesOrigin))
+	return s
+}
+
+// tripsSummary describes trips by the routes and dates they cover
+func tripsSummary(trips []api.Trip) export.Summary {
+	s := export.Summary{Title: "Trips"}
+
+	routes := map[string]bool{}
+	dates := map[string]bool{}
+	for _, t := range trips {
+		segs := t.AvailabilitySegments
+		if len(segs) > 0 {
+			routes[segs[0].OriginAirport+" → "+segs[len(segs)-1].DestinationAirport] = true
+		}
+		dates[t.DepartsAt.Format("2006-01-02")] = true
+	}
+
+	s.Add("Routes", joinSorted(routes))
+	s.Add("Dates", joinSorted(dates))
+	return s
+}
+
+// resultsSummary describes availability results by their routes and dates,
+// for reports exported without the original flags
+func resultsSummary(results []api.Availability) export.Summary {
+	s := export.Summary{Title: "Award availability"}
+
+	routes := map[string]bool{}
+	var first, last string
+	for _, a := range results {
+		routes[a.Route.OriginAirport+" → "+a.Route.DestinationAirport] = true
+		if first == "" || a.Date < first {
+			first = a.Date
+		}
+		if a.Date > last {
+			last = a.Date
+		}
+	}
+
+	s.Add("Routes", joinSorted(routes))
+	s.Add("Dates", dateRange(first, last))
+	return s
+}
+
+func dateRange(start, end string) string {
+	switch {
+	case start == "" && end == "":
+		return ""
+	case start == end || end == "":
+		return start
+	case start == "":
+		return "until " + end
+	default:
+		return start + " – " + end
+	}
+}
+
+func joinSorted(set map[string]bool) string {
+	list := make([]string, 0, len(set))
+	for k := range set {
+		list = append(list, k)
+	}
+	sort.Strings(list)
+	return strings.Join(list, ", ")
+}
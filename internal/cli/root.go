@@ -3,10 +3,13 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/JHill6253/seats-aero-cli/internal/api"
 	"github.com/JHill6253/seats-aero-cli/internal/config"
+	"github.com/JHill6253/seats-aero-cli/internal/valuation"
 )
 
 var (
@@ -64,6 +67,14 @@ func GetConfig() *config.Config {
 	return cfg
 }
 
+// pointValues returns the configured point valuations on top of the defaults
+func pointValues() valuation.Table {
+	if cfg == nil {
+		return valuation.Table{}
+	}
+	return valuation.New(cfg.Valuations)
+}
+
 func runGuided() error {
 	if cfg == nil {
 		var err error
@@ -97,6 +108,8 @@ var configShowCmd = &cobra.Command{
 		fmt.Printf("  Default Sources: %v\n", cfg.DefaultSources)
 		fmt.Printf("  Default Cabins: %v\n", cfg.DefaultCabins)
 		fmt.Printf("  Preferred Airports: %v\n", cfg.PreferredAirports)
+		fmt.Printf("  Notification Sinks: %d\n", len(cfg.Notifications))
+		fmt.Printf("  Point Valuation Overrides: %d\n", len(cfg.Valuations))
 
 		if path, err := config.ConfigPath(); err == nil {
 			fmt.Printf("\nConfig file path: %s\n", path)
@@ -104,9 +117,38 @@ var configShowCmd = &cobra.Command{
 	},
 }
 
+var configValuationsCmd = &cobra.Command{
+	Use:   "valuations",
+	Short: "Show the cents-per-point valuation used for each program",
+	Long: `Show the cents-per-point valuation used for each program when estimating
+the effective cost of an award. Override any of them in the config file:
+
+  valuations:
+    aeroplan: 1.6
+    delta: 1.1`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var overrides map[string]float64
+		if cfg != nil {
+			overrides = cfg.Valuations
+		}
+		vals := pointValues()
+
+		fmt.Printf("%-16s %-25s %-6s\n", "Source", "Program", "¢/pt")
+		fmt.Println(strings.Repeat("-", 52))
+		for _, source := range api.ValidSources() {
+			note := ""
+			if _, ok := overrides[source]; ok {
+				note = "(configured)"
+			}
+			fmt.Printf("%-16s %-25s %-6.2f %s\n", source, api.SourceDisplayName(source), vals.CPP(source), note)
+		}
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
 	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configValuationsCmd)
 }
 
 func maskAPIKey(key string) string {
@@ -16,16 +16,33 @@ var routesCmd = &cobra.Command{
 	Short: "List available routes",
 	Long: `List routes available for a mileage program.
 
+Map outputs (geojson, kml) place airports from a bundled list of about 170
+major airports; routes touching any other airport are left off the map and
+listed on stderr.
+
 Examples:
   seats routes --source aeroplan
-  seats routes --source united --origin SFO`,
+  seats routes --source united --origin SFO
+  seats routes --source united --output ndjson --envelope
+  seats routes --source united --stats
+  seats routes --source aeroplan --origin YVR --map
+  seats routes --source aeroplan --output geojson > aeroplan.geojson
+  seats routes --source united --origin SFO --output kml > united-sfo.kml
+  seats routes --source united --output template --template '{{.OriginAirport}}-{{.DestinationAirport}} {{.Distance}}'`,
 	RunE: runRoutes,
 }
 
 var (
-	routesSource string
-	routesOrigin string
-	routesOutput string
+	routesSource   string
+	routesOrigin   string
+	routesOutput   string
+	routesEnvelope bool
+	routesTemplate string
+	routesTmplFile string
+	routesStats    bool
+	routesTop      int
+	routesMap      bool
+	routesMapWidth int
 )
 
 func init() {
@@ -33,7 +50,14 @@ func init() {
 
 	routesCmd.Flags().StringVar(&routesSource, "source", "", "Mileage program source")
 	routesCmd.Flags().StringVar(&routesOrigin, "origin", "", "Filter by origin airport")
-	routesCmd.Flags().StringVarP(&routesOutput, "output", "o", "table", "Output format: table, json, csv")
+	routesCmd.Flags().StringVarP(&routesOutput, "output", "o", "table", "Output format: table, json, csv, ndjson, markdown, html, template, geojson, kml")
+	routesCmd.Flags().StringVar(&routesTemplate, "template", "", templateHelp)
+	routesCmd.Flags().StringVar(&routesTmplFile, "template-file", "", "File containing the --output template")
+	routesCmd.Flags().BoolVar(&routesEnvelope, "envelope", false, "With ndjson output, write a first line with the query metadata")
+	routesCmd.Flags().BoolVar(&routesStats, "stats", false, "Show network statistics: hubs, countries, distances, longest and shortest routes")
+	routesCmd.Flags().IntVar(&routesTop, "top", 10, "Number of hubs and longest/shortest routes in --stats")
+	routesCmd.Flags().BoolVar(&routesMap, "map", false, "Plot the routes from --origin on a terminal world map")
+	routesCmd.Flags().IntVar(&routesMapWidth, "map-width", 100, "Width of the --map plot in characters")
 }
 
 func runRoutes(cmd *cobra.Command, args []string) error {
@@ -46,6 +70,10 @@ func runRoutes(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if routesMap && routesOrigin == "" {
+		return fmt.Errorf("--map requires --origin")
+	}
+
 	client := api.NewClient(cfg.GetAPIKey())
 
 	params := api.RoutesParams{
@@ -58,11 +86,26 @@ func runRoutes(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("get routes failed: %w", err)
 	}
 
+	if routesStats || routesMap {
+		return showRoutesNetwork(resp.Data)
+	}
+
 	switch strings.ToLower(routesOutput) {
 	case "json":
 		return export.RoutesToJSON(os.Stdout, resp.Data, true)
 	case "csv":
 		return export.RoutesToCSV(os.Stdout, resp.Data)
+	case "markdown", "md":
+		return export.RoutesToMarkdown(os.Stdout, resp.Data, routesSummary())
+	case "html":
+		return export.RoutesToHTML(os.Stdout, resp.Data, routesSummary())
+	case "template":
+		return writeTemplate(routesTemplate, routesTmplFile, resp.Data)
+	case "ndjson":
+		return writeNDJSON(routesEnvelope, "routes", params, resp.Data)
+	case "geojson", "kml":
+		lines, missing := export.RouteLines(resp.Data)
+		return writeMap(routesOutput, mapName("routes", params.Source, params.Origin), lines, missing)
 	default:
 		printRoutesResults(resp.Data)
 	}
@@ -0,0 +1,414 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/config"
+	"github.com/JHill6253/seats-aero-cli/internal/export"
+	"github.com/JHill6253/seats-aero-cli/internal/filter"
+	"github.com/JHill6253/seats-aero-cli/internal/notify"
+	"github.com/JHill6253/seats-aero-cli/internal/saved"
+	"github.com/JHill6253/seats-aero-cli/internal/schedule"
+)
+
+var savedCmd = &cobra.Command{
+	Use:   "saved",
+	Short: "Manage and run saved searches",
+	Long: `Store named searches and run them again later.
+
+Saved searches live in saved.yaml next to config.yaml. Dates may be absolute
+(YYYY-MM-DD) or relative to the day the search runs (today, +14d, +2w, +3m).
+
+Examples:
+  seats saved add tokyo-j --from SFO,LAX --to NRT,HND --cabin J --start-date +30d --end-date +90d
+  seats saved add tokyo-j --from SFO --to NRT --max-miles 80000 --min-seats 2 --notify team-slack --force
+  seats saved list
+  seats saved show tokyo-j
+  seats saved run tokyo-j
+  seats saved run --all
+  seats saved rm tokyo-j`,
+}
+
+var savedAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Save a search",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSavedAdd,
+}
+
+var savedListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved searches",
+	Args:  cobra.NoArgs,
+	RunE:  runSavedList,
+}
+
+var savedShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show a saved search",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSavedShow,
+}
+
+var savedRunCmd = &cobra.Command{
+	Use:   "run [name...]",
+	Short: "Run one or more saved searches",
+	RunE:  runSavedRun,
+}
+
+var savedRmCmd = &cobra.Command{
+	Use:     "rm <name>",
+	Aliases: []string{"remove"},
+	Short:   "Delete a saved search",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runSavedRm,
+}
+
+var (
+	savedFrom        string
+	savedTo          string
+	savedStartDate   string
+	savedEndDate     string
+	savedCabin       string
+	savedSource      string
+	savedDirect      bool
+	savedCabins      string
+	savedMaxMiles    int
+	savedMinSeats    int
+	savedOutput      string
+	savedNotify      string
+	savedForce       bool
+	savedSchedule    string
+	savedRunAll      bool
+	savedRunOutput   string
+	savedRunNoNotify bool
+)
+
+func init() {
+	rootCmd.AddCommand(savedCmd)
+	savedCmd.AddCommand(savedAddCmd, savedListCmd, savedShowCmd, savedRunCmd, savedRmCmd)
+
+	savedAddCmd.Flags().StringVar(&savedFrom, "from", "", "Origin airport(s), comma-separated (required)")
+	savedAddCmd.Flags().StringVar(&savedTo, "to", "", "Destination airport(s), comma-separated (required)")
+	savedAddCmd.Flags().StringVar(&savedStartDate, "start-date", "", "Start date (YYYY-MM-DD, today, +14d, +2w, +3m)")
+	savedAddCmd.Flags().StringVar(&savedEndDate, "end-date", "", "End date (YYYY-MM-DD, today, +14d, +2w, +3m)")
+	savedAddCmd.Flags().StringVar(&savedCabin, "cabin", "", "Cabin class: Y/economy, W/premium, J/business, F/first")
+	savedAddCmd.Flags().StringVar(&savedSource, "source", "", "Mileage program source(s), comma-separated")
+	savedAddCmd.Flags().BoolVar(&savedDirect, "direct-only", false, "Only show direct flights")
+	savedAddCmd.Flags().StringVar(&savedCabins, "filter-cabins", "", "Only keep results with these cabins available (Y,W,J,F)")
+	savedAddCmd.Flags().IntVar(&savedMaxMiles, "max-miles", 0, "Only keep cabins at or below this mileage cost")
+	savedAddCmd.Flags().IntVar(&savedMinSeats, "min-seats", 0, "Only keep cabins with at least this many seats")
+	savedAddCmd.Flags().StringVarP(&savedOutput, "output", "o", "", "Default output format: table, json, csv")
+	savedAddCmd.Flags().StringVar(&savedNotify, "notify", "", "Notification sink name(s) to alert when results are found, comma-separated")
+	savedAddCmd.Flags().StringVar(&savedSchedule, "schedule", "", "Schedule for seats daemon: cron expression (\"0 */6 * * *\") or \"@every 2h\"")
+	savedAddCmd.Flags().BoolVar(&savedForce, "force", false, "Replace an existing saved search with the same name")
+	savedAddCmd.MarkFlagRequired("from")
+	savedAddCmd.MarkFlagRequired("to")
+
+	savedRunCmd.Flags().BoolVar(&savedRunAll, "all", false, "Run every saved search")
+	savedRunCmd.Flags().StringVarP(&savedRunOutput, "output", "o", "", "Output format: table, json, csv (default: the saved search's format)")
+	savedRunCmd.Flags().BoolVar(&savedRunNoNotify, "no-notify", false, "Don't send notifications")
+}
+
+func loadSavedStore() (*saved.Store, error) {
+	path, err := saved.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return saved.Load(path)
+}
+
+func runSavedAdd(cmd *cobra.Command, args []string) error {
+	store, err := loadSavedStore()
+	if err != nil {
+		return err
+	}
+
+	search := saved.Search{
+		Name:       args[0],
+		From:       parseCSV(savedFrom),
+		To:         parseCSV(savedTo),
+		StartDate:  strings.TrimSpace(savedStartDate),
+		EndDate:    strings.TrimSpace(savedEndDate),
+		Cabin:      cabinCodeToName(strings.ToUpper(savedCabin)),
+		Sources:    parseCSVLower(savedSource),
+		DirectOnly: savedDirect,
+		Filters: filter.Options{
+			Cabins:   parseCSV(savedCabins),
+			MaxMiles: savedMaxMiles,
+			MinSeats: savedMinSeats,
+		},
+		Output:   strings.ToLower(savedOutput),
+		Notify:   splitNames(savedNotify),
+		Schedule: strings.TrimSpace(savedSchedule),
+	}
+
+	// Validate dates and schedule up front so a typo doesn't surface on the next run
+	if _, err := search.Params(time.Now()); err != nil {
+		return err
+	}
+	if search.Schedule != "" {
+		if _, err := schedule.Parse(search.Schedule); err != nil {
+			return err
+		}
+	}
+
+	if err := store.Put(search, savedForce); err != nil {
+		if !savedForce {
+			return fmt.Errorf("%w (use --force to replace it)", err)
+		}
+		return err
+	}
+	if err := store.Save(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Saved search %q to %s\n", search.Name, store.Path())
+	return nil
+}
+
+func runSavedList(cmd *cobra.Command, args []string) error {
+	store, err := loadSavedStore()
+	if err != nil {
+		return err
+	}
+
+	if len(store.Searches) == 0 {
+		fmt.Println("No saved searches.")
+		return nil
+	}
+
+	fmt.Printf("%-20s %-25s %-12s %-12s %-10s %-15s %s\n", "Name", "Route", "Start", "End", "Cabin", "Schedule", "Sources")
+	fmt.Println(strings.Repeat("-", 110))
+	for _, s := range store.Searches {
+		fmt.Printf("%-20s %-25s %-12s %-12s %-10s %-15s %s\n",
+			s.Name,
+			s.Describe(),
+			valueOr(s.StartDate, "-"),
+			valueOr(s.EndDate, "-"),
+			valueOr(s.Cabin, "all"),
+			valueOr(s.Schedule, "-"),
+			valueOr(strings.Join(s.Sources, ","), "all"),
+		)
+	}
+	return nil
+}
+
+func runSavedShow(cmd *cobra.Command, args []string) error {
+	store, err := loadSavedStore()
+	if err != nil {
+		return err
+	}
+
+	search, ok := store.Get(args[0])
+	if !ok {
+		return fmt.Errorf("saved search %q not found", args[0])
+	}
+
+	data, err := yaml.Marshal(search)
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(data))
+	return nil
+}
+
+func runSavedRm(cmd *cobra.Command, args []string) error {
+	store, err := loadSavedStore()
+	if err != nil {
+		return err
+	}
+
+	if err := store.Remove(args[0]); err != nil {
+		return err
+	}
+	if err := store.Save(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Removed saved search %q\n", args[0])
+	return nil
+}
+
+// savedRunResult is the outcome of running a single saved search
+type savedRunResult struct {
+	Name    string             `json:"name"`
+	Route   string             `json:"route"`
+	Results []api.Availability `json:"results"`
+	Error   string             `json:"error,omitempty"`
+}
+
+func runSavedRun(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if cfg == nil {
+		return fmt.Errorf("configuration not loaded")
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	store, err := loadSavedStore()
+	if err != nil {
+		return err
+	}
+
+	var searches []saved.Search
+	switch {
+	case savedRunAll:
+		searches = store.Searches
+	case len(args) == 0:
+		return fmt.Errorf("specify saved search name(s) or --all")
+	default:
+		for _, name := range args {
+			search, ok := store.Get(name)
+			if !ok {
+				return fmt.Errorf("saved search %q not found", name)
+			}
+			searches = append(searches, search)
+		}
+	}
+
+	if len(searches) == 0 {
+		fmt.Println("No saved searches.")
+		return nil
+	}
+
+	output := savedRunOutput
+	if output == "" && len(searches) == 1 {
+		output = searches[0].Output
+	}
+
+	results := runSavedSearches(cfg, searches, !savedRunNoNotify)
+
+	switch strings.ToLower(output) {
+	case "json":
+		return export.WriteJSON(os.Stdout, results, true)
+	case "csv":
+		var all []api.Availability
+		for _, r := range results {
+			all = append(all, r.Results...)
+		}
+		return export.ToCSV(os.Stdout, all, pointValues())
+	default:
+		printSavedReport(results)
+	}
+
+	return nil
+}
+
+// runSavedSearches executes each saved search, applies its filters and
+// dispatches notifications for searches with results
+func runSavedSearches(cfg *config.Config, searches []saved.Search, alert bool) []savedRunResult {
+	client := api.NewClient(cfg.GetAPIKey())
+	now := time.Now()
+
+	results := make([]savedRunResult, 0, len(searches))
+	for _, search := range searches {
+		result := savedRunResult{Name: search.Name, Route: search.Describe()}
+
+		params, err := search.Params(now)
+		if err != nil {
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		resp, err := client.Search(params)
+		if err != nil {
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+		result.Results = filter.Availability(resp.Data, search.Filters)
+
+		if alert && len(search.Notify) > 0 && len(result.Results) > 0 {
+			if err := notifySaved(cfg, search, result.Results); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", search.Name, err)
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func notifySaved(cfg *config.Config, search saved.Search, results []api.Availability) error {
+	notifiers, err := selectNotifiers(cfg.Notifications, search.Notify)
+	if err != nil {
+		return err
+	}
+	return notify.Dispatch(notifiers, notify.ResultsMessage(search.Name, results))
+}
+
+func printSavedReport(results []savedRunResult) {
+	for _, r := range results {
+		fmt.Println(titleStyle.Render(fmt.Sprintf("%s (%s)", r.Name, r.Route)))
+		if r.Error != "" {
+			fmt.Printf("Error: %s\n\n", r.Error)
+			continue
+		}
+		printSearchResults(r.Results, nil)
+		fmt.Println()
+	}
+
+	if len(results) < 2 {
+		return
+	}
+
+	// Combined summary of the cheapest option per cabin for each search
+	fmt.Println(titleStyle.Render("Summary"))
+	fmt.Printf("%-20s %-25s %-8s %-8s %-8s %-8s %-8s\n", "Name", "Route", "Results", "Y", "W", "J", "F")
+	fmt.Println(strings.Repeat("-", 90))
+	for _, r := range results {
+		count := fmt.Sprintf("%d", len(r.Results))
+		if r.Error != "" {
+			count = "error"
+		}
+		row := []interface{}{r.Name, r.Route, count}
+		for _, cabin := range api.ValidCabins() {
+			row = append(row, cheapestCabin(r.Results, cabin))
+		}
+		fmt.Printf("%-20s %-25s %-8s %-8s %-8s %-8s %-8s\n", row...)
+	}
+}
+
+// cheapestCabin returns the lowest mileage cost for a cabin across results
+func cheapestCabin(results []api.Availability, cabin string) string {
+	best := 0
+	for _, a := range results {
+		c := a.Cabin(cabin)
+		if c.Available && c.Miles > 0 && (best == 0 || c.Miles < best) {
+			best = c.Miles
+		}
+	}
+	if best == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%dk", best/1000)
+}
+
+func splitNames(s string) []string {
+	var names []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			names = append(names, p)
+		}
+	}
+	return names
+}
+
+func valueOr(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
@@ -2,13 +2,18 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/JHill6253/seats-aero-cli/internal/api"
 	"github.com/JHill6253/seats-aero-cli/internal/export"
+	"github.com/JHill6253/seats-aero-cli/internal/filter"
+	"github.com/JHill6253/seats-aero-cli/internal/tui"
+	"github.com/JHill6253/seats-aero-cli/internal/valuation"
 )
 
 var searchCmd = &cobra.Command{
@@ -16,11 +21,36 @@ var searchCmd = &cobra.Command{
 	Short: "Search for award flight availability",
 	Long: `Search for cached award flight availability between airports.
 
+Map outputs (geojson, kml) place airports from a bundled list of about 170
+major airports; routes touching any other airport are left off the map and
+listed on stderr.
+
 Examples:
   seats search --from SFO --to NRT --start-date 2024-06-01
   seats search --from SFO,LAX --to NRT,HND --cabin J --source united,aeroplan
   seats search --from SFO --to NRT --start-date 2024-06-01 --end-date 2024-06-15 --output json
-  seats search --from SFO --to NRT --direct-only --output csv > results.csv`,
+  seats search --from SFO --to NRT --direct-only --output csv > results.csv
+  seats search --from SFO --to NRT --cabin J --tui
+  seats search --from SFO --to NRT,HND --cabin J --rank-by value
+  seats search --from SFO --to NRT --cabin J --passengers 3
+  seats search --from SFO --to NRT --cabin J --with-trips --output csv > flights.csv
+  seats search --from SFO --to NRT --output ndjson --envelope | jq -c .
+  seats search --from SFO --to NRT --cabin J --output html > report.html
+  seats search --from SFO --to NRT --fields date,origin,dest,J.miles --output csv
+  seats search --from SFO --to NRT --cabin J --with-trips --output xlsx --out trips.xlsx
+  seats search --from SFO --to NRT --with-trips --output sqlite --out awards.db
+  seats search --from SFO,LAX --to NRT,HND,ICN --cabin J --output geojson > awards.geojson
+  seats search --to NRT --cabin J --source aeroplan,united --positioning --positioning-awards
+  seats search --from SFO --to NRT --output template --template '{{.Date}} {{.Route.OriginAirport}} {{miles .JMileageCost}}'
+
+With --output ndjson every page of results is fetched and written one record
+per line as it arrives.
+
+--positioning adds alternate departures near your home airports: the --from
+airports, or preferred_airports from the config only when --from is left
+out. It costs one routes call per --source program, plus the positioning
+award search with --positioning-awards, all rate limited and counted against
+your daily quota.`,
 	RunE: runSearch,
 }
 
@@ -33,22 +63,56 @@ var (
 	searchSource    string
 	searchDirect    bool
 	searchOutput    string
+	searchTUI       bool
+	searchRankBy    string
+	searchPax       int
+	searchWithTrips bool
+	searchTripsConc int
+	searchGranular  string
+	searchEnvelope  bool
+	searchTemplate  string
+	searchTmplFile  string
+	searchFields    string
+	searchOutFile   string
+	searchPosition  bool
+	searchPosRadius int
+	searchPosMax    int
+	searchPosAwards bool
 )
 
 func init() {
 	rootCmd.AddCommand(searchCmd)
 
-	searchCmd.Flags().StringVar(&searchFrom, "from", "", "Origin airport(s), comma-separated (required)")
+	searchCmd.Flags().StringVar(&searchFrom, "from", "", "Origin airport(s), comma-separated (required unless --positioning uses preferred airports)")
 	searchCmd.Flags().StringVar(&searchTo, "to", "", "Destination airport(s), comma-separated (required)")
 	searchCmd.Flags().StringVar(&searchStartDate, "start-date", "", "Start date (YYYY-MM-DD)")
 	searchCmd.Flags().StringVar(&searchEndDate, "end-date", "", "End date (YYYY-MM-DD)")
 	searchCmd.Flags().StringVar(&searchCabin, "cabin", "", "Cabin class: Y/economy, W/premium, J/business, F/first")
 	searchCmd.Flags().StringVar(&searchSource, "source", "", "Mileage program source(s), comma-separated")
 	searchCmd.Flags().BoolVar(&searchDirect, "direct-only", false, "Only show direct flights")
-	searchCmd.Flags().StringVarP(&searchOutput, "output", "o", "table", "Output format: table, json, csv")
+	searchCmd.Flags().StringVarP(&searchOutput, "output", "o", "table", "Output format: table, json, csv, ndjson, markdown, html, template, xlsx, sqlite, geojson, kml")
+	searchCmd.Flags().BoolVar(&searchEnvelope, "envelope", false, "With ndjson output, write a first line with the query metadata")
+	searchCmd.Flags().StringVar(&searchOutFile, "out", "", "File to write xlsx or sqlite output to")
+	searchCmd.Flags().StringVar(&searchTemplate, "template", "", templateHelp)
+	searchCmd.Flags().StringVar(&searchTmplFile, "template-file", "", "File containing the --output template")
+	searchCmd.Flags().StringVar(&searchFields, "fields", "", "Columns for table, csv and json output: "+export.FieldNames())
+	searchCmd.Flags().BoolVar(&searchTUI, "tui", false, "Browse results in a full-screen interactive table")
+	searchCmd.Flags().BoolVar(&searchWithTrips, "with-trips", false, "Also fetch the trips for every result and nest them in the output")
+	searchCmd.Flags().StringVar(&searchGranular, "granularity", "trip", "With --with-trips, CSV rows and JSON trips per trip or per flight segment: trip, segment")
+	searchCmd.Flags().IntVar(&searchTripsConc, "concurrency", defaultTripsConcurrency, "Trip lookups to run in parallel with --with-trips")
+	searchCmd.Flags().IntVar(&searchPax, "passengers", 1, "Number of passengers; drops options without enough seats and prices for the party")
+	searchCmd.Flags().StringVar(&searchRankBy, "rank-by", "", "Rank results by: value, miles, date (default: API order)")
+
+	searchCmd.Flags().BoolVar(&searchPosition, "positioning", false, "Also search alternate departures near your home airports (--from, else preferred_airports), noting the positioning flight; needs --source")
+	searchCmd.Flags().IntVar(&searchPosRadius, "positioning-radius", 750, "With --positioning, how far from home in miles an alternate departure may be")
+	searchCmd.Flags().IntVar(&searchPosMax, "positioning-max", 5, "With --positioning, the most alternate departures to add")
+	searchCmd.Flags().BoolVar(&searchPosAwards, "positioning-awards", false, "With --positioning, also search award space for the positioning flights")
 
-	searchCmd.MarkFlagRequired("from")
 	searchCmd.MarkFlagRequired("to")
+	searchCmd.MarkFlagsMutuallyExclusive("tui", "output")
+	searchCmd.MarkFlagsMutuallyExclusive("tui", "fields")
+	searchCmd.MarkFlagsMutuallyExclusive("tui", "with-trips")
+	searchCmd.MarkFlagsMutuallyExclusive("with-trips", "fields")
 }
 
 func runSearch(cmd *cobra.Command, args []string) error {
@@ -61,7 +125,34 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if err := validatePassengers(searchPax); err != nil {
+		return err
+	}
+
+	if err := checkOutFile(searchOutFile, searchOutput); err != nil {
+		return err
+	}
+
+	if err := checkFields(searchFields, searchOutput); err != nil {
+		return err
+	}
+
+	if err := checkTemplate(searchTemplate, searchTmplFile, searchOutput); err != nil {
+		return err
+	}
+
+	if searchFrom == "" && !searchPosition {
+		return fmt.Errorf(`required flag(s) "from" not set`)
+	}
+	if searchPosition && searchSource == "" {
+		return fmt.Errorf("--positioning needs --source to choose the programs whose routes are loaded")
+	}
+
+	// Positioning and trip lookups make many calls, so space them out
 	client := api.NewClient(cfg.GetAPIKey())
+	if searchPosition || searchWithTrips {
+		client.WithRateLimiter(api.NewRateLimiter(defaultTripsRateLimit))
+	}
 
 	params := api.SearchParams{
 		OriginAirports:      parseCSV(searchFrom),
@@ -73,23 +164,169 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		DirectOnly:          searchDirect,
 	}
 
+	var plan positioningPlan
+	if searchPosition {
+		var err error
+		if plan, err = planPositioning(client, cfg, &params); err != nil {
+			return err
+		}
+	}
+
+	if isNDJSON(searchOutput) {
+		results, err := streamSearch(client, params)
+		if err != nil {
+			return err
+		}
+		plan.print(os.Stderr, results)
+		return nil
+	}
+
 	resp, err := client.Search(params)
 	if err != nil {
 		return fmt.Errorf("search failed: %w", err)
 	}
 
+	cabins := valueCabins(searchCabin)
+	results, combos := partyResults(resp.Data, searchPax, cabins)
+	if err := rankResults(results, searchRankBy, pointValues(), cabins); err != nil {
+		return err
+	}
+
+	// notes are party and positioning suggestions, kept off stdout for data formats
+	notes := func(w io.Writer) {
+		printPartySuggestions(w, combos, searchPax)
+		plan.print(w, results)
+	}
+
+	if searchWithTrips {
+		granularity, err := export.ParseGranularity(searchGranular)
+		if err != nil {
+			return err
+		}
+		notes(os.Stderr)
+		items := fetchAvailabilityTrips(client, results, searchTripsConc, filter.TripOptions{MaxStops: -1}, searchPax)
+		target := outputTarget{
+			path:         searchOutFile,
+			template:     searchTemplate,
+			templateFile: searchTmplFile,
+			mapName:      mapName("search", searchFrom, "to", searchTo),
+			command:      "search",
+			query:        searchQuery(params),
+			passengers:   searchPax,
+		}
+		return writeAvailabilityTrips(items, searchOutput, granularity, target)
+	}
+
+	if searchTUI {
+		notes(os.Stdout)
+		return browseResults(client, results, searchPax)
+	}
+
+	if searchFields != "" {
+		notes(os.Stderr)
+		return writeFields(results, searchFields, searchOutput)
+	}
+
 	switch strings.ToLower(searchOutput) {
 	case "json":
-		return export.ToJSON(os.Stdout, resp.Data, true)
+		notes(os.Stderr)
+		return export.WriteJSON(os.Stdout, walletJSON(results, cabins), true)
 	case "csv":
-		return export.ToCSV(os.Stdout, resp.Data)
+		notes(os.Stderr)
+		return export.ToCSV(os.Stdout, results, pointValues(), walletColumns(cabins)...)
+	case "markdown", "md":
+		notes(os.Stderr)
+		return export.ToMarkdown(os.Stdout, results, searchSummary(), pointValues())
+	case "html":
+		notes(os.Stderr)
+		return export.ToHTML(os.Stdout, results, searchSummary(), pointValues())
+	case "xlsx":
+		notes(os.Stderr)
+		return writeXLSX(searchOutFile, export.Workbook{Availability: results})
+	case "sqlite":
+		notes(os.Stderr)
+		target := outputTarget{path: searchOutFile, command: "search", query: searchQuery(params), passengers: searchPax}
+		return writeSQLite(target, export.Workbook{Availability: results})
+	case "template":
+		notes(os.Stderr)
+		return writeTemplate(searchTemplate, searchTmplFile, results)
+	case "geojson", "kml":
+		notes(os.Stderr)
+		lines, missing := export.AvailabilityLines(results, pointValues())
+		return writeMap(searchOutput, mapName("search", searchFrom, "to", searchTo), lines, missing)
 	default:
-		printSearchResults(resp.Data)
+		printSearchResults(results, cabins)
+		notes(os.Stdout)
 	}
 
 	return nil
 }
 
+// streamSearch writes every page of search results as NDJSON as it arrives.
+// With --positioning it also returns the date and origin of each result for
+// the positioning summary.
+func streamSearch(client *api.Client, params api.SearchParams) ([]api.Availability, error) {
+	if err := checkStreamRanking(searchRankBy); err != nil {
+		return nil, err
+	}
+	if searchWithTrips {
+		client.WithRateLimiter(api.NewRateLimiter(defaultTripsRateLimit))
+	}
+
+	out, err := newNDJSONOutput(searchEnvelope, "search", searchQuery(params))
+	if err != nil {
+		return nil, err
+	}
+
+	stream := availabilityStream{
+		client:      client,
+		passengers:  searchPax,
+		cabins:      valueCabins(searchCabin),
+		withTrips:   searchWithTrips,
+		concurrency: searchTripsConc,
+	}
+
+	var results []api.Availability
+	if searchPosition {
+		results = []api.Availability{}
+		stream.seen = func(page []api.Availability) {
+			for _, a := range page {
+				results = append(results, api.Availability{Date: a.Date, Route: api.Route{OriginAirport: a.Route.OriginAirport}})
+			}
+		}
+	}
+	if err := client.SearchEach(params, stream.write(out)); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// searchQuery describes a search for NDJSON envelopes and SQLite fetch records
+func searchQuery(params api.SearchParams) interface{} {
+	return struct {
+		api.SearchParams
+		Passengers int  `json:"passengers"`
+		WithTrips  bool `json:"with_trips,omitempty"`
+	}{params, searchPax, searchWithTrips}
+}
+
+// browseResults opens the interactive results browser, fetching trips on
+// demand. Trips are narrowed and priced for the number of passengers.
+func browseResults(client *api.Client, results []api.Availability, passengers int) error {
+	if len(results) == 0 {
+		fmt.Println("No results found.")
+		return nil
+	}
+
+	return tui.Browse(results, func(id string) ([]api.Trip, error) {
+		resp, err := client.GetTrips(id)
+		if err != nil {
+			return nil, err
+		}
+		return partyTrips(resp.Data, passengers), nil
+	})
+}
+
 func parseCSV(s string) []string {
 	if s == "" {
 		return nil
@@ -105,7 +342,9 @@ func parseCSV(s string) []string {
 	return result
 }
 
-func printSearchResults(results []api.Availability) {
+// printSearchResults prints results as a table; the value column is the
+// lowest effective cost among cabins (all cabins when empty)
+func printSearchResults(results []api.Availability, cabins []string) {
 	if len(results) == 0 {
 		fmt.Println("No results found.")
 		return
@@ -113,18 +352,25 @@ func printSearchResults(results []api.Availability) {
 
 	fmt.Printf("Found %d results:\n\n", len(results))
 
+	vals := pointValues()
+	w := userWallet()
+
 	// Print header
-	fmt.Printf("%-12s %-5s %-5s %-15s %-8s %-8s %-8s %-8s\n",
-		"Date", "From", "To", "Source", "Y", "W", "J", "F")
-	fmt.Println(strings.Repeat("-", 80))
+	header := fmt.Sprintf("%-12s %-5s %-5s %-15s %-8s %-8s %-8s %-8s %-8s",
+		"Date", "From", "To", "Source", "Y", "W", "J", "F", "Value")
+	if !w.IsEmpty() {
+		header += " Bookable"
+	}
+	fmt.Println(header)
+	fmt.Println(strings.Repeat("-", 90))
 
 	for _, a := range results {
-		yInfo := formatCabinInfo(a.YAvailable, a.YMileageCost, a.YRemainingSeats)
-		wInfo := formatCabinInfo(a.WAvailable, a.WMileageCost, a.WRemainingSeats)
-		jInfo := formatCabinInfo(a.JAvailable, a.JMileageCost, a.JRemainingSeats)
-		fInfo := formatCabinInfo(a.FAvailable, a.FMileageCost, a.FRemainingSeats)
+		yInfo := formatCabin(a, "Y")
+		wInfo := formatCabin(a, "W")
+		jInfo := formatCabin(a, "J")
+		fInfo := formatCabin(a, "F")
 
-		fmt.Printf("%-12s %-5s %-5s %-15s %-8s %-8s %-8s %-8s\n",
+		row := fmt.Sprintf("%-12s %-5s %-5s %-15s %-8s %-8s %-8s %-8s %-8s",
 			a.Date,
 			a.Route.OriginAirport,
 			a.Route.DestinationAirport,
@@ -133,8 +379,80 @@ func printSearchResults(results []api.Availability) {
 			wInfo,
 			jInfo,
 			fInfo,
+			formatValueCell(vals, a, cabins),
 		)
+		if !w.IsEmpty() {
+			row += " " + formatBookable(w, a, cabins)
+		}
+		fmt.Println(row)
+	}
+}
+
+// valueCabins returns the cabin codes to value results by, from a --cabin flag
+func valueCabins(cabin string) []string {
+	if cabin == "" {
+		return nil
+	}
+	return []string{api.CabinCode(cabin)}
+}
+
+// formatValueCell renders the lowest effective cost among cabins, e.g. "J $812"
+func formatValueCell(vals valuation.Table, a api.Availability, cabins []string) string {
+	v, cabin, ok := vals.Best(a, cabins...)
+	if !ok {
+		return "-"
 	}
+	return cabin + " " + v.String()
+}
+
+// rankResults sorts results in place by value (lowest effective cost), miles
+// (fewest) or date. An empty key keeps the API order.
+func rankResults(results []api.Availability, by string, vals valuation.Table, cabins []string) error {
+	var key func(a api.Availability) (float64, bool)
+	switch strings.ToLower(by) {
+	case "":
+		return nil
+	case "date":
+		sort.SliceStable(results, func(i, j int) bool { return results[i].Date < results[j].Date })
+		return nil
+	case "value":
+		key = func(a api.Availability) (float64, bool) {
+			v, _, ok := vals.Best(a, cabins...)
+			return v.Cost, ok
+		}
+	case "miles":
+		key = func(a api.Availability) (float64, bool) {
+			miles, ok := cheapestMiles(a, cabins)
+			return float64(miles), ok
+		}
+	default:
+		return fmt.Errorf("invalid --rank-by %q (use value, miles or date)", by)
+	}
+
+	// Results without availability in the ranked cabins sort last
+	sort.SliceStable(results, func(i, j int) bool {
+		ki, oki := key(results[i])
+		kj, okj := key(results[j])
+		if oki != okj {
+			return oki
+		}
+		return ki < kj
+	})
+	return nil
+}
+
+func cabinsOrAll(cabins []string) []string {
+	if len(cabins) == 0 {
+		return api.ValidCabins()
+	}
+	return cabins
+}
+
+// formatCabin formats one cabin of a result for the tables, priced for the
+// party
+func formatCabin(a api.Availability, code string) string {
+	c := a.Cabin(code)
+	return formatCabinInfo(c.Available, c.MileageCost, c.RemainingSeats)
 }
 
 func formatCabinInfo(available bool, miles string, seats int) string {
@@ -150,20 +468,5 @@ func formatCabinInfo(available bool, miles string, seats int) string {
 // cabinCodeToName converts cabin codes (Y/W/J/F) to API names (economy/premium/business/first)
 // If already a valid name or empty, returns as-is (lowercased)
 func cabinCodeToName(code string) string {
-	code = strings.TrimSpace(code)
-	if code == "" {
-		return ""
-	}
-	switch strings.ToUpper(code) {
-	case "Y", "ECONOMY":
-		return "economy"
-	case "W", "PREMIUM":
-		return "premium"
-	case "J", "BUSINESS":
-		return "business"
-	case "F", "FIRST":
-		return "first"
-	default:
-		return strings.ToLower(code)
-	}
+	return api.CabinParam(code)
 }
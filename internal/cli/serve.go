@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/server"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run a local HTTP JSON API",
+	Long: `Expose search, availability, routes and trips as a local HTTP JSON API so
+other tools can query award data without holding the seats.aero API key.
+
+Upstream responses are cached, results can be filtered with query parameters,
+and upstream calls are rate limited and counted against a daily quota. The
+OpenAPI document is served at /openapi.json.
+
+Examples:
+  seats serve
+  seats serve --addr 127.0.0.1:9000 --token s3cret
+  curl -H "Authorization: Bearer s3cret" "http://127.0.0.1:9000/v1/search?from=SFO&to=NRT&cabin=J"`,
+	RunE: runServe,
+}
+
+var (
+	serveAddr     string
+	serveToken    string
+	serveCacheTTL time.Duration
+)
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "", "Listen address (default from config, 127.0.0.1:8686)")
+	serveCmd.Flags().StringVar(&serveToken, "token", "", "Require this bearer token from clients (or SEATS_SERVE_TOKEN)")
+	serveCmd.Flags().DurationVar(&serveCacheTTL, "cache-ttl", 0, "How long to cache upstream responses, 0 to disable (default from config, 10m)")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	cfg := GetConfig()
+	if cfg == nil {
+		return fmt.Errorf("configuration not loaded")
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	addr := cfg.Serve.Addr
+	if cmd.Flags().Changed("addr") {
+		addr = serveAddr
+	}
+	token := cfg.Serve.Token
+	if envToken := os.Getenv("SEATS_SERVE_TOKEN"); envToken != "" {
+		token = envToken
+	}
+	if cmd.Flags().Changed("token") {
+		token = serveToken
+	}
+	cacheTTL := cfg.Serve.CacheTTL
+	if cmd.Flags().Changed("cache-ttl") {
+		cacheTTL = serveCacheTTL
+	}
+
+	quota := api.NewQuota(cfg.Serve.DailyQuota)
+	client := api.NewClient(cfg.GetAPIKey()).
+		WithRateLimiter(api.NewRateLimiter(cfg.Serve.RateLimit)).
+		WithQuota(quota)
+
+	logger := log.New(os.Stderr, "seats: ", log.LstdFlags)
+
+	srv := server.New(server.Options{
+		Client:   client,
+		Quota:    quota,
+		Token:    token,
+		CacheTTL: cacheTTL,
+		Logger:   logger,
+	})
+
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           srv.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		if token == "" {
+			logger.Printf("warning: no bearer token configured, API is unauthenticated")
+		}
+		logger.Printf("listening on http://%s", addr)
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	logger.Printf("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return httpServer.Shutdown(shutdownCtx)
+}
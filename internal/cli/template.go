@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/export"
+)
+
+// templateHelp documents --template for command help
+const templateHelp = "Go template applied to each record with --output template; helpers: cabin, source, miles, milesk, upper, lower, join"
+
+// outputTemplate loads the --template or --template-file text
+func outputTemplate(text, file string) (*template.Template, error) {
+	switch {
+	case text != "" && file != "":
+		return nil, fmt.Errorf("pass either --template or --template-file, not both")
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+		text = string(data)
+	case text == "":
+		return nil, fmt.Errorf("--output template requires --template or --template-file")
+	}
+	return export.ParseTemplate(text)
+}
+
+// writeTemplate renders each record with the --template or --template-file text
+func writeTemplate[T any](text, file string, records []T) error {
+	tmpl, err := outputTemplate(text, file)
+	if err != nil {
+		return err
+	}
+	return export.WriteTemplate(os.Stdout, tmpl, records)
+}
+
+// checkTemplate validates --template and --template-file before any API
+// calls when the output is a template
+func checkTemplate(text, file, output string) error {
+	if strings.ToLower(output) != "template" {
+		return nil
+	}
+	_, err := outputTemplate(text, file)
+	return err
+}
+
+// checkFields validates --fields before any API calls, rejecting unknown
+// fields and outputs that don't apply the selection
+func checkFields(spec, output string) error {
+	if spec == "" {
+		return nil
+	}
+	if _, err := export.ParseFields(spec); err != nil {
+		return err
+	}
+	switch strings.ToLower(output) {
+	case "json", "csv", "table", "":
+		return nil
+	default:
+		return fmt.Errorf("--fields applies to table, csv and json output, not %s", output)
+	}
+}
+
+// writeFields outputs only the selected --fields of each result as a table,
+// CSV or JSON
+func writeFields(results []api.Availability, spec, output string) error {
+	fields, err := export.ParseFields(spec)
+	if err != nil {
+		return err
+	}
+
+	vals := pointValues()
+	switch strings.ToLower(output) {
+	case "json":
+		return export.FieldsToJSON(os.Stdout, results, fields, vals, true)
+	case "csv":
+		return export.FieldsToCSV(os.Stdout, results, fields, vals)
+	case "table", "":
+		printFieldsTable(results, fields)
+		return nil
+	default:
+		return fmt.Errorf("--fields applies to table, csv and json output, not %s", output)
+	}
+}
+
+func printFieldsTable(results []api.Availability, fields []export.Field) {
+	if len(results) == 0 {
+		fmt.Println("No results found.")
+		return
+	}
+
+	fmt.Printf("Found %d results:\n\n", len(results))
+
+	vals := pointValues()
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	fmt.Fprintln(tw, strings.Join(names, "\t"))
+
+	for _, a := range results {
+		cells := make([]string, len(fields))
+		for i, f := range fields {
+			cells[i] = f.Text(a, vals)
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	tw.Flush()
+}
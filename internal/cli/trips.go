@@ -1,36 +1,118 @@
 package cli
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/JHill6253/seats-aero-cli/internal/airports"
 	"github.com/JHill6253/seats-aero-cli/internal/api"
 	"github.com/JHill6253/seats-aero-cli/internal/export"
+	"github.com/JHill6253/seats-aero-cli/internal/filter"
+	"github.com/JHill6253/seats-aero-cli/internal/valuation"
 )
 
 var tripsCmd = &cobra.Command{
-	Use:   "trips <availability-id>",
-	Short: "Get trip details for an availability",
-	Long: `Retrieve detailed flight information for a specific availability.
+	Use:   "trips <availability-id>...",
+	Short: "Get trip details for one or more availabilities",
+	Long: `Retrieve detailed flight information for specific availabilities.
 
-The availability ID is returned from search or availability commands.
+Availability IDs are returned from search or availability commands. Pass
+several IDs, or "-" to read them from stdin one per line, to look up and
+filter trips in a batch. With --from-file, trips are fetched for every result
+in a saved "search --output json" file and nested under their availability.
+Batch lookups run concurrently under a shared rate limit.
+
+Trip filters compare times of day in the local time of the airport involved.
+A red-eye is a segment leaving 21:00-03:59 and landing 04:00-09:59 local; an
+overnight layover spans local midnight at the connecting airport. These checks
+are skipped at airports without a bundled time zone, and for trips without
+segment details, which never match --carriers or --aircraft.
 
 Examples:
   seats trips abc123def456
-  seats trips abc123def456 --output json`,
-	Args: cobra.ExactArgs(1),
+  seats trips abc123def456 --output json
+  seats trips abc123def456 --output ics > flight.ics
+  seats trips abc123def456 --output xlsx --out trips.xlsx
+  seats trips abc123def456 --rank-by value
+  seats trips abc123def456 --passengers 2
+  seats trips abc123 def456 --max-stops 1 --max-layover 3h --no-redeye
+  seats trips abc123 --depart-window 08:00-14:00 --carriers NH,UA --exclude-aircraft 789
+  seats search --from SFO --to NRT --output json | jq -r '.[].ID' | seats trips - --max-duration 14h
+  seats trips --from-file results.json --output csv --granularity segment > flights.csv`,
 	RunE: runTrips,
 }
 
-var tripsOutput string
+var (
+	tripsOutput string
+	tripsRankBy string
+	tripsPax    int
+
+	tripsMaxStops        int
+	tripsMinLayover      time.Duration
+	tripsMaxLayover      time.Duration
+	tripsNoOvernight     bool
+	tripsNoRedEye        bool
+	tripsDepartWindow    string
+	tripsArriveWindow    string
+	tripsCarriers        string
+	tripsExcludeCarriers string
+	tripsAircraft        string
+	tripsExcludeAircraft string
+	tripsMaxDuration     time.Duration
+
+	tripsFromFile    string
+	tripsConcurrency int
+	tripsRateLimit   int
+	tripsGranularity string
+	tripsEnvelope    bool
+	tripsTemplate    string
+	tripsTmplFile    string
+	tripsOutFile     string
+)
+
+// Defaults for batch trip lookups
+const (
+	defaultTripsConcurrency = 4
+	defaultTripsRateLimit   = 60 // requests per minute
+)
 
 func init() {
 	rootCmd.AddCommand(tripsCmd)
 
-	tripsCmd.Flags().StringVarP(&tripsOutput, "output", "o", "table", "Output format: table, json, csv")
+	tripsCmd.Flags().StringVarP(&tripsOutput, "output", "o", "table", "Output format: table, json, csv, ndjson, markdown, html, ics, template, xlsx, sqlite")
+	tripsCmd.Flags().StringVar(&tripsOutFile, "out", "", "File to write xlsx or sqlite output to")
+	tripsCmd.Flags().StringVar(&tripsTemplate, "template", "", templateHelp)
+	tripsCmd.Flags().StringVar(&tripsTmplFile, "template-file", "", "File containing the --output template")
+	tripsCmd.Flags().BoolVar(&tripsEnvelope, "envelope", false, "With ndjson output, write a first line with the query metadata")
+	tripsCmd.Flags().StringVar(&tripsGranularity, "granularity", "trip", "Rows per trip or per flight segment in CSV/JSON: trip, segment")
+	tripsCmd.Flags().IntVar(&tripsPax, "passengers", 1, "Number of passengers; drops trips without enough seats and prices for the party")
+	tripsCmd.Flags().StringVar(&tripsRankBy, "rank-by", "", "Rank trips by: value, miles, duration, within each result with --from-file (default: API order)")
+
+	tripsCmd.Flags().IntVar(&tripsMaxStops, "max-stops", -1, "Maximum number of stops")
+	tripsCmd.Flags().DurationVar(&tripsMinLayover, "min-layover", 0, "Minimum connection time, e.g. 1h")
+	tripsCmd.Flags().DurationVar(&tripsMaxLayover, "max-layover", 0, "Maximum connection time, e.g. 4h30m")
+	tripsCmd.Flags().BoolVar(&tripsNoOvernight, "no-overnight", false, "Exclude layovers that span local midnight")
+	tripsCmd.Flags().BoolVar(&tripsNoRedEye, "no-redeye", false, "Exclude overnight red-eye segments")
+	tripsCmd.Flags().StringVar(&tripsDepartWindow, "depart-window", "", "Local departure time window, e.g. 08:00-14:00")
+	tripsCmd.Flags().StringVar(&tripsArriveWindow, "arrive-window", "", "Local arrival time window, e.g. 12:00-22:00")
+	tripsCmd.Flags().StringVar(&tripsCarriers, "carriers", "", "Only trips where every segment is on these carriers, comma-separated")
+	tripsCmd.Flags().StringVar(&tripsExcludeCarriers, "exclude-carriers", "", "Exclude trips with a segment on these carriers")
+	tripsCmd.Flags().StringVar(&tripsAircraft, "aircraft", "", "Only trips with a segment on these aircraft codes, e.g. 388,77W")
+	tripsCmd.Flags().StringVar(&tripsExcludeAircraft, "exclude-aircraft", "", "Exclude trips with a segment on these aircraft codes")
+	tripsCmd.Flags().DurationVar(&tripsMaxDuration, "max-duration", 0, "Maximum total trip duration, e.g. 14h")
+
+	tripsCmd.Flags().StringVar(&tripsFromFile, "from-file", "", "Fetch trips for every result in a search JSON file")
+	tripsCmd.Flags().IntVar(&tripsConcurrency, "concurrency", defaultTripsConcurrency, "Trip lookups to run in parallel")
+	tripsCmd.Flags().IntVar(&tripsRateLimit, "rate-limit", defaultTripsRateLimit, "Maximum trip lookups per minute")
 }
 
 func runTrips(cmd *cobra.Command, args []string) error {
@@ -43,21 +125,125 @@ func runTrips(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	availabilityID := args[0]
-	client := api.NewClient(cfg.GetAPIKey())
+	if err := validatePassengers(tripsPax); err != nil {
+		return err
+	}
+
+	if err := checkOutFile(tripsOutFile, tripsOutput); err != nil {
+		return err
+	}
+
+	if err := checkTemplate(tripsTemplate, tripsTmplFile, tripsOutput); err != nil {
+		return err
+	}
+
+	opts, err := tripFilterOptions()
+	if err != nil {
+		return err
+	}
+
+	granularity, err := export.ParseGranularity(tripsGranularity)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(cfg.GetAPIKey()).
+		WithRateLimiter(api.NewRateLimiter(tripsRateLimit))
+
+	if tripsFromFile != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("pass either availability IDs or --from-file, not both")
+		}
+		// Check --rank-by before spending API calls on the lookups
+		vals := pointValues()
+		if err := rankTrips(nil, tripsRankBy, vals); err != nil {
+			return err
+		}
+		results, err := readResultsFile(tripsFromFile)
+		if err != nil {
+			return err
+		}
+		items := fetchAvailabilityTrips(client, results, tripsConcurrency, opts, tripsPax)
+		for _, item := range items {
+			rankTrips(item.Trips, tripsRankBy, vals)
+		}
+		query := map[string]interface{}{"from_file": tripsFromFile, "passengers": tripsPax}
+		if isNDJSON(tripsOutput) {
+			return writeNDJSON(tripsEnvelope, "trips", query, items)
+		}
+		target := outputTarget{
+			path:         tripsOutFile,
+			template:     tripsTemplate,
+			templateFile: tripsTmplFile,
+			mapName:      mapName("trips"),
+			command:      "trips",
+			query:        query,
+			passengers:   tripsPax,
+		}
+		return writeAvailabilityTrips(items, tripsOutput, granularity, target)
+	}
 
-	resp, err := client.GetTrips(availabilityID)
+	ids, err := availabilityIDs(args)
 	if err != nil {
-		return fmt.Errorf("get trips failed: %w", err)
+		return err
+	}
+
+	// A failed ID is reported and skipped so it doesn't lose the rest of the
+	// batch, as with TripsError for --from-file
+	var trips []api.Trip
+	var failed []error
+	for _, r := range client.GetTripsBatch(ids, tripsConcurrency) {
+		if r.Err != nil {
+			err := fmt.Errorf("get trips for %s failed: %w", r.AvailabilityID, r.Err)
+			if len(ids) > 1 {
+				fmt.Fprintf(os.Stderr, "Skipping: %v\n", err)
+			}
+			failed = append(failed, err)
+			continue
+		}
+		trips = append(trips, r.Trips...)
+	}
+	switch {
+	case len(ids) == 1 && len(failed) == 1:
+		return failed[0]
+	case len(failed) == len(ids):
+		return fmt.Errorf("get trips failed for all %d availability IDs", len(ids))
+	}
+
+	trips = partyTrips(filter.Trips(trips, opts), tripsPax)
+	if err := rankTrips(trips, tripsRankBy, pointValues()); err != nil {
+		return err
 	}
 
 	switch strings.ToLower(tripsOutput) {
 	case "json":
-		return export.TripsToJSON(os.Stdout, resp.Data, true)
+		if granularity == export.GranularitySegment {
+			return export.SegmentsToJSON(os.Stdout, trips, true)
+		}
+		return export.TripsToJSON(os.Stdout, trips, true)
 	case "csv":
-		return export.TripsToCSV(os.Stdout, resp.Data)
+		if granularity == export.GranularitySegment {
+			return export.SegmentsToCSV(os.Stdout, trips)
+		}
+		return export.TripsToCSV(os.Stdout, trips, pointValues())
+	case "markdown", "md":
+		return export.TripsToMarkdown(os.Stdout, trips, tripsSummary(trips), pointValues())
+	case "html":
+		return export.TripsToHTML(os.Stdout, trips, tripsSummary(trips), pointValues())
+	case "xlsx":
+		return writeXLSX(tripsOutFile, export.Workbook{Trips: trips})
+	case "sqlite":
+		query := map[string]interface{}{"availability_ids": ids, "passengers": tripsPax}
+		return writeSQLite(outputTarget{path: tripsOutFile, command: "trips", query: query, passengers: tripsPax}, export.Workbook{Trips: trips})
+	case "template":
+		return writeTemplate(tripsTemplate, tripsTmplFile, trips)
+	case "ndjson":
+		query := map[string]interface{}{"availability_ids": ids, "passengers": tripsPax}
+		return writeNDJSON(tripsEnvelope, "trips", query, trips)
+	case "ics":
+		return writeICS(os.Stdout, export.Workbook{Trips: trips})
 	default:
-		printTripsResults(resp.Data)
+		printTripsResults(trips)
 	}
 
 	return nil
@@ -71,32 +257,258 @@ func printTripsResults(trips []api.Trip) {
 
 	fmt.Printf("Found %d trips:\n\n", len(trips))
 
+	vals := pointValues()
+
 	for i, t := range trips {
 		fmt.Printf("Trip %d: %s\n", i+1, t.Cabin)
 		fmt.Printf("  Flights: %s\n", t.FlightNumbers)
 		fmt.Printf("  Carriers: %s\n", t.Carriers)
 		fmt.Printf("  Stops: %d\n", t.Stops)
 		fmt.Printf("  Duration: %dh %dm\n", t.TotalDuration/60, t.TotalDuration%60)
-		fmt.Printf("  Miles: %d\n", t.MileageCost)
-		if t.TotalTaxes > 0 {
-			fmt.Printf("  Taxes: %s%.2f\n", t.TaxesCurrencySymbol, float64(t.TotalTaxes)/100)
+		fmt.Printf("  Miles: %d\n", t.PartyMiles())
+		if t.PartyTaxes() > 0 {
+			fmt.Printf("  Taxes: %s%s\n", t.TaxesCurrencySymbol, api.FormatTaxes(t.PartyTaxes(), t.TaxesCurrency))
 		}
+		v := vals.Trip(t)
+		fmt.Printf("  Value: %s (%.2f¢/pt)\n", v, v.CPP)
 		fmt.Printf("  Seats: %d\n", t.RemainingSeats)
 		fmt.Printf("  Departs: %s\n", t.DepartsAt.Format("2006-01-02 15:04"))
 		fmt.Printf("  Arrives: %s\n", t.ArrivesAt.Format("2006-01-02 15:04"))
 
 		if len(t.AvailabilitySegments) > 0 {
 			fmt.Println("  Segments:")
-			for _, seg := range t.AvailabilitySegments {
+			for j, seg := range t.AvailabilitySegments {
+				if j > 0 {
+					prev := t.AvailabilitySegments[j-1]
+					note := ""
+					if filter.IsOvernight(prev, seg) {
+						note = ", overnight"
+					}
+					fmt.Printf("      layover %s at %s%s\n", formatDuration(seg.DepartsAt.Sub(prev.ArrivesAt)), seg.OriginAirport, note)
+				}
+				// DepartsAt is a UTC instant from the API; show it in the
+				// origin's local time (UTC when the airport isn't bundled)
 				fmt.Printf("    %s: %s -> %s (%s %s)\n",
 					seg.FlightNumber,
 					seg.OriginAirport,
 					seg.DestinationAirport,
 					seg.AircraftCode,
-					seg.DepartsAt.Format("15:04"),
+					airports.Local(seg.OriginAirport, seg.DepartsAt).Format("15:04"),
 				)
 			}
 		}
 		fmt.Println()
 	}
 }
+
+// readResultsFile loads availability results saved with --output json or
+// --output ndjson, skipping any envelope line
+func readResultsFile(path string) ([]api.Availability, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results: %w", err)
+	}
+
+	var results []api.Availability
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		for decoder.More() {
+			var line struct {
+				api.Availability
+				Type string `json:"type"`
+			}
+			if err := decoder.Decode(&line); err != nil {
+				return nil, fmt.Errorf("failed to parse %s as search results: %w", path, err)
+			}
+			if line.Type != "envelope" {
+				results = append(results, line.Availability)
+			}
+		}
+		return results, nil
+	}
+
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse %s as search results: %w", path, err)
+	}
+	return results, nil
+}
+
+// fetchAvailabilityTrips looks up trips for every result concurrently and
+// attaches them to their availability, applying trip filters and party size.
+// Lookup failures are recorded per availability rather than aborting.
+func fetchAvailabilityTrips(client *api.Client, results []api.Availability, concurrency int, opts filter.TripOptions, passengers int) []export.AvailabilityTrips {
+	ids := make([]string, len(results))
+	for i, a := range results {
+		ids[i] = a.ID
+	}
+
+	fmt.Fprintf(os.Stderr, "Fetching trips for %d results...\n", len(ids))
+
+	items := make([]export.AvailabilityTrips, len(results))
+	for i, r := range client.GetTripsBatch(ids, concurrency) {
+		items[i] = export.AvailabilityTrips{Availability: results[i]}
+		if r.Err != nil {
+			items[i].TripsError = r.Err.Error()
+			continue
+		}
+		items[i].Trips = partyTrips(filter.Trips(r.Trips, opts), passengers)
+	}
+	return items
+}
+
+// writeAvailabilityTrips outputs results with their trips as nested JSON, a
+// flattened CSV with one row per trip or segment, calendar events, a report
+// of the trips, an XLSX workbook or SQLite database saved to the target,
+// the target's template applied to each result, a map of the results, or a
+// table
+func writeAvailabilityTrips(items []export.AvailabilityTrips, output string, granularity export.Granularity, target outputTarget) error {
+	switch strings.ToLower(output) {
+	case "json":
+		return export.NestedToJSON(os.Stdout, items, true, granularity)
+	case "csv":
+		return export.NestedToCSV(os.Stdout, items, pointValues(), granularity)
+	case "ics":
+		return writeICS(os.Stdout, nestedWorkbook(items))
+	case "markdown", "md":
+		trips := nestedWorkbook(items).Trips
+		return export.TripsToMarkdown(os.Stdout, trips, tripsSummary(trips), pointValues())
+	case "html":
+		trips := nestedWorkbook(items).Trips
+		return export.TripsToHTML(os.Stdout, trips, tripsSummary(trips), pointValues())
+	case "xlsx":
+		return writeXLSX(target.path, nestedWorkbook(items))
+	case "sqlite":
+		return writeSQLite(target, nestedWorkbook(items))
+	case "template":
+		return writeTemplate(target.template, target.templateFile, items)
+	case "geojson", "kml":
+		lines, missing := export.AvailabilityLines(nestedWorkbook(items).Availability, pointValues())
+		return writeMap(output, target.mapName, lines, missing)
+	default:
+		printAvailabilityTrips(items)
+	}
+	return nil
+}
+
+// writeICS writes the trips of a workbook as calendar events, warning about
+// airports without a bundled time zone whose times are written in UTC
+func writeICS(w io.Writer, wb export.Workbook) error {
+	if missing := export.UnzonedAirports(wb.Trips, wb.Availability); len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "Times in UTC for airports without a known time zone: %s\n", strings.Join(missing, ", "))
+	}
+	return export.TripsToICS(w, wb.Trips, wb.Availability)
+}
+
+func printAvailabilityTrips(items []export.AvailabilityTrips) {
+	if len(items) == 0 {
+		fmt.Println("No results found.")
+		return
+	}
+
+	for _, a := range items {
+		fmt.Println(titleStyle.Render(fmt.Sprintf("%s %s → %s · %s",
+			a.Date, a.Route.OriginAirport, a.Route.DestinationAirport, api.SourceDisplayName(a.Source))))
+
+		switch {
+		case a.TripsError != "":
+			fmt.Printf("Error: %s\n\n", a.TripsError)
+			continue
+		case len(a.Trips) == 0:
+			fmt.Printf("No matching trips.\n\n")
+			continue
+		}
+
+		fmt.Printf("%-9s %-28s %-6s %-9s %-8s %-6s %s\n", "Cabin", "Flights", "Stops", "Duration", "Miles", "Seats", "Departs")
+		for _, t := range a.Trips {
+			fmt.Printf("%-9s %-28s %-6d %-9s %-8s %-6d %s\n",
+				t.Cabin, t.FlightNumbers, t.Stops, formatDuration(time.Duration(t.TotalDuration)*time.Minute),
+				formatMilesK(t.PartyMiles()), t.RemainingSeats, t.DepartsAt.Format("2006-01-02 15:04"))
+		}
+		fmt.Println()
+	}
+}
+
+// tripFilterOptions builds trip filters from the trips command flags
+func tripFilterOptions() (filter.TripOptions, error) {
+	depart, err := filter.ParseWindow(tripsDepartWindow)
+	if err != nil {
+		return filter.TripOptions{}, err
+	}
+	arrive, err := filter.ParseWindow(tripsArriveWindow)
+	if err != nil {
+		return filter.TripOptions{}, err
+	}
+
+	return filter.TripOptions{
+		MaxStops:        tripsMaxStops,
+		MinLayover:      tripsMinLayover,
+		MaxLayover:      tripsMaxLayover,
+		NoOvernight:     tripsNoOvernight,
+		NoRedEye:        tripsNoRedEye,
+		MaxDuration:     tripsMaxDuration,
+		DepartWindow:    depart,
+		ArriveWindow:    arrive,
+		Carriers:        parseCSV(tripsCarriers),
+		ExcludeCarriers: parseCSV(tripsExcludeCarriers),
+		Aircraft:        parseCSV(tripsAircraft),
+		ExcludeAircraft: parseCSV(tripsExcludeAircraft),
+	}, nil
+}
+
+// availabilityIDs expands the trips arguments, reading IDs from stdin for "-"
+func availabilityIDs(args []string) ([]string, error) {
+	var ids []string
+	seen := map[string]bool{}
+	add := func(id string) {
+		if id = strings.TrimSpace(id); id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, arg := range args {
+		if arg != "-" {
+			add(arg)
+			continue
+		}
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			add(scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read IDs from stdin: %w", err)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no availability IDs given")
+	}
+	return ids, nil
+}
+
+// rankTrips sorts trips in place by value (lowest effective cost), miles or
+// duration. An empty key keeps the API order.
+func rankTrips(trips []api.Trip, by string, vals valuation.Table) error {
+	var key func(t api.Trip) float64
+	switch strings.ToLower(by) {
+	case "":
+		return nil
+	case "value":
+		key = func(t api.Trip) float64 { return vals.Trip(t).Cost }
+	case "miles":
+		key = func(t api.Trip) float64 { return float64(t.PartyMiles()) }
+	case "duration":
+		key = func(t api.Trip) float64 { return float64(t.TotalDuration) }
+	default:
+		return fmt.Errorf("invalid --rank-by %q (use value, miles or duration)", by)
+	}
+
+	sort.SliceStable(trips, func(i, j int) bool { return key(trips[i]) < key(trips[j]) })
+	return nil
+}
+
+// formatDuration renders a duration as hours and minutes, e.g. "2h 15m"
+func formatDuration(d time.Duration) string {
+	minutes := int(d.Minutes())
+	return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+}
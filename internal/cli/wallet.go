@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/export"
+	"github.com/JHill6253/seats-aero-cli/internal/wallet"
+)
+
+var walletCmd = &cobra.Command{
+	Use:   "wallet",
+	Short: "Show point balances and the programs they can reach",
+	Long: `Show the balances in the wallet section of the config file and, for each
+mileage program, how many miles they can reach directly or through transfer
+partners.
+
+Configure balances per program or transferable currency:
+
+  wallet:
+    balances:
+      amex: 250000
+      chase: 120000
+      aeroplan: 15000
+    transfers:        # optional overrides of the bundled ratios
+      amex:
+        emirates: 0.8
+
+Transferable currencies: ` + strings.Join(currencyIDs(), ", ") + `. Any other
+balance is a program source such as aeroplan or united.
+
+Examples:
+  seats wallet
+  seats wallet --all`,
+	RunE: runWallet,
+}
+
+var walletAll bool
+
+func init() {
+	rootCmd.AddCommand(walletCmd)
+
+	walletCmd.Flags().BoolVar(&walletAll, "all", false, "Also list programs the wallet cannot reach")
+}
+
+func runWallet(cmd *cobra.Command, args []string) error {
+	if err := validateWallet(); err != nil {
+		return err
+	}
+
+	w := userWallet()
+	if w.IsEmpty() {
+		fmt.Println("No balances configured. Add a wallet section to the config file (see seats wallet --help).")
+		return nil
+	}
+
+	fmt.Println(titleStyle.Render("Balances"))
+	var ids []string
+	for id := range cfg.Wallet.Balances {
+		ids = append(ids, strings.ToLower(id))
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		fmt.Printf("  %-16s %10d  %s\n", id, w.Balance(id), walletDisplayName(w, id))
+	}
+	fmt.Println()
+
+	fmt.Println(titleStyle.Render("Reachable programs"))
+	fmt.Printf("%-16s %-25s %-10s %s\n", "Source", "Program", "Miles", "Via")
+	fmt.Println(strings.Repeat("-", 80))
+	for _, source := range api.ValidSources() {
+		c := w.Cover(source, 0, 1)
+		if c.Available == 0 && !walletAll {
+			continue
+		}
+		fmt.Printf("%-16s %-25s %-10d %s\n", source, api.SourceDisplayName(source), c.Available, c.Via())
+	}
+
+	return nil
+}
+
+// userWallet returns the wallet configured in the config file
+func userWallet() wallet.Wallet {
+	if cfg == nil {
+		return wallet.New(nil, nil)
+	}
+	return wallet.New(cfg.Wallet.Balances, cfg.Wallet.Transfers)
+}
+
+// validateWallet checks that every balance is held in a known program or
+// transferable currency
+func validateWallet() error {
+	if cfg == nil {
+		return nil
+	}
+
+	known := map[string]bool{}
+	for _, id := range currencyIDs() {
+		known[id] = true
+	}
+	for _, source := range api.ValidSources() {
+		known[source] = true
+	}
+	for id := range cfg.Wallet.Transfers {
+		known[strings.ToLower(id)] = true
+	}
+
+	for id := range cfg.Wallet.Balances {
+		if !known[strings.ToLower(id)] {
+			return fmt.Errorf("unknown wallet balance %q (use a program source or one of: %s)", id, strings.Join(currencyIDs(), ", "))
+		}
+	}
+	return nil
+}
+
+// currencyIDs lists the bundled transferable currencies
+func currencyIDs() []string {
+	var ids []string
+	for _, c := range wallet.Currencies() {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
+
+func walletDisplayName(w wallet.Wallet, id string) string {
+	if name := w.CurrencyName(id); name != id {
+		return name
+	}
+	return api.SourceDisplayName(id)
+}
+
+// formatBookable describes whether the wallet covers the cheapest of the
+// given cabins, e.g. "✓ aeroplan, amex 1:1", "✗ chase 1:1 (short 40000)" or
+// "✗ via amex, citi" with no balances held. Results priced with party.Scale
+// already carry the cost for every passenger.
+func formatBookable(w wallet.Wallet, a api.Availability, cabins []string) string {
+	miles, ok := cheapestMiles(a, cabins)
+	if !ok {
+		return "-"
+	}
+
+	c := w.Cover(a.Source, miles, 1)
+	if len(c.Paths) == 0 {
+		// Nothing held; still show which currencies could fund it
+		var partners []string
+		for _, p := range w.Paths(a.Source) {
+			partners = append(partners, p.From)
+		}
+		if len(partners) == 0 {
+			return "✗ no balance"
+		}
+		return "✗ via " + strings.Join(partners, ", ")
+	}
+	if c.Covered {
+		return "✓ " + c.Via()
+	}
+	return fmt.Sprintf("✗ %s (short %d)", c.Via(), c.Cost-c.Available)
+}
+
+// cheapestMiles returns the lowest mileage price among the given cabins
+// (all cabins when empty)
+func cheapestMiles(a api.Availability, cabins []string) (int, bool) {
+	miles, found := 0, false
+	for _, code := range cabinsOrAll(cabins) {
+		c := a.Cabin(code)
+		if c.Available && c.Miles > 0 && (!found || c.Miles < miles) {
+			miles, found = c.Miles, true
+		}
+	}
+	return miles, found
+}
+
+// bookableResult is an availability with the wallet's coverage of its
+// cheapest cabin, for JSON output
+type bookableResult struct {
+	api.Availability
+	Bookable *wallet.Coverage `json:"Bookable"`
+}
+
+// walletJSON adds wallet coverage to results when a wallet is configured
+func walletJSON(results []api.Availability, cabins []string) interface{} {
+	w := userWallet()
+	if w.IsEmpty() {
+		return results
+	}
+
+	out := make([]bookableResult, len(results))
+	for i, a := range results {
+		out[i].Availability = a
+		if miles, ok := cheapestMiles(a, cabins); ok {
+			c := w.Cover(a.Source, miles, 1)
+			out[i].Bookable = &c
+		}
+	}
+	return out
+}
+
+// walletColumns adds the Bookable column to CSV output when a wallet is
+// configured
+func walletColumns(cabins []string) []export.Column {
+	w := userWallet()
+	if w.IsEmpty() {
+		return nil
+	}
+	return []export.Column{{
+		Name:  "Bookable",
+		Value: func(a api.Availability) string { return formatBookable(w, a, cabins) },
+	}}
+}
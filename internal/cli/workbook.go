@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/export"
+)
+
+// outputTarget is the --out file for binary formats, the --template or
+// --template-file text, the map name, and a description of the command run
+// that produced the data
+type outputTarget struct {
+	path         string
+	template     string
+	templateFile string
+	mapName      string
+	command      string
+	query        interface{}
+	passengers   int
+}
+
+// checkOutFile validates --out before any API calls: xlsx and sqlite need a
+// file, and the other formats write to stdout so can't take one
+func checkOutFile(path, output string) error {
+	switch output = strings.ToLower(output); output {
+	case "xlsx", "sqlite":
+		if path == "" {
+			return fmt.Errorf("--output %s requires --out <file>", output)
+		}
+		return nil
+	default:
+		if path != "" {
+			return fmt.Errorf("--out applies to xlsx and sqlite output, not %s", output)
+		}
+		return nil
+	}
+}
+
+// writeSQLite upserts the data into the SQLite database at the --out path
+func writeSQLite(target outputTarget, wb export.Workbook) error {
+	if target.path == "" {
+		return fmt.Errorf("--output sqlite requires --out <file.db>")
+	}
+
+	fetch := export.Fetch{Command: target.command, Query: target.query, Passengers: target.passengers, FetchedAt: time.Now()}
+	if err := export.ToSQLite(target.path, wb, fetch); err != nil {
+		return fmt.Errorf("failed to export SQLite: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Saved %d results and %d trips to %s\n", len(wb.Availability), len(wb.Trips), target.path)
+	return nil
+}
+
+// writeXLSX saves a workbook to the --out path. XLSX is binary, so unlike
+// the text formats it is never written to stdout.
+func writeXLSX(path string, wb export.Workbook) error {
+	if path == "" {
+		return fmt.Errorf("--output xlsx requires --out <file.xlsx>")
+	}
+	path = withExtension(path, ExportXLSX)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	if err := export.ToXLSX(f, wb, pointValues()); err != nil {
+		return fmt.Errorf("failed to export XLSX: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported to %s\n", path)
+	return nil
+}
+
+// nestedWorkbook splits availabilities and their trips into workbook sheets
+func nestedWorkbook(items []export.AvailabilityTrips) export.Workbook {
+	var wb export.Workbook
+	for _, item := range items {
+		wb.Availability = append(wb.Availability, item.Availability)
+		wb.Trips = append(wb.Trips, item.Trips...)
+	}
+	return wb
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -14,6 +15,69 @@ type Config struct {
 	DefaultSources    []string `mapstructure:"default_sources"`
 	DefaultCabins     []string `mapstructure:"default_cabins"`
 	PreferredAirports []string `mapstructure:"preferred_airports"`
+
+	// Valuations overrides the default cents-per-point value of a program
+	Valuations map[string]float64 `mapstructure:"valuations"`
+	Wallet     WalletConfig       `mapstructure:"wallet"`
+
+	Notifications []NotifierConfig `mapstructure:"notifications"`
+	Daemon        DaemonConfig     `mapstructure:"daemon"`
+	Serve         ServeConfig      `mapstructure:"serve"`
+}
+
+// WalletConfig holds point balances and transfer partner overrides
+type WalletConfig struct {
+	// Balances are keyed by program source (e.g. aeroplan) or transferable
+	// currency (amex, chase, citi, capitalone, bilt)
+	Balances map[string]int `mapstructure:"balances"`
+	// Transfers overrides transfer ratios, keyed by currency then program;
+	// a ratio of 0 removes a partner
+	Transfers map[string]map[string]float64 `mapstructure:"transfers"`
+}
+
+// DaemonConfig holds settings for the long-running scheduler
+type DaemonConfig struct {
+	RateLimit   int    `mapstructure:"rate_limit"`   // API requests per minute
+	DailyQuota  int    `mapstructure:"daily_quota"`  // API requests per day, 0 for unlimited
+	HealthAddr  string `mapstructure:"health_addr"`  // empty disables the health endpoint
+	HistoryKeep int    `mapstructure:"history_keep"` // snapshots kept per search, 0 for all
+}
+
+// ServeConfig holds settings for the local HTTP API server
+type ServeConfig struct {
+	Addr       string        `mapstructure:"addr"`
+	Token      string        `mapstructure:"token"` // bearer token required from clients, empty to disable auth
+	CacheTTL   time.Duration `mapstructure:"cache_ttl"`
+	RateLimit  int           `mapstructure:"rate_limit"`  // upstream API requests per minute
+	DailyQuota int           `mapstructure:"daily_quota"` // upstream API requests per day, 0 for unlimited
+}
+
+// NotifierConfig describes a single notification sink
+type NotifierConfig struct {
+	Name     string `mapstructure:"name"`
+	Type     string `mapstructure:"type"` // webhook, slack, discord, email, ntfy, command
+	Template string `mapstructure:"template"`
+
+	// HTTP sinks (webhook, slack, discord, ntfy)
+	URL     string            `mapstructure:"url"`
+	Headers map[string]string `mapstructure:"headers"`
+
+	// ntfy
+	Priority string   `mapstructure:"priority"`
+	Tags     []string `mapstructure:"tags"`
+	Token    string   `mapstructure:"token"`
+
+	// Email
+	SMTPHost string   `mapstructure:"smtp_host"`
+	SMTPPort int      `mapstructure:"smtp_port"`
+	Username string   `mapstructure:"username"`
+	Password string   `mapstructure:"password"`
+	From     string   `mapstructure:"from"`
+	To       []string `mapstructure:"to"`
+
+	// Local command
+	Command string   `mapstructure:"command"`
+	Args    []string `mapstructure:"args"`
 }
 
 // Load reads the configuration from file and environment variables
@@ -38,6 +102,14 @@ func Load() (*Config, error) {
 	viper.SetDefault("default_sources", []string{})
 	viper.SetDefault("default_cabins", []string{"J", "F"})
 	viper.SetDefault("preferred_airports", []string{})
+	viper.SetDefault("daemon.rate_limit", 10)
+	viper.SetDefault("daemon.daily_quota", 1000)
+	viper.SetDefault("daemon.health_addr", "127.0.0.1:8787")
+	viper.SetDefault("daemon.history_keep", 100)
+	viper.SetDefault("serve.addr", "127.0.0.1:8686")
+	viper.SetDefault("serve.cache_ttl", "10m")
+	viper.SetDefault("serve.rate_limit", 30)
+	viper.SetDefault("serve.daily_quota", 1000)
 
 	// Read config file (ignore if not found)
 	if err := viper.ReadInConfig(); err != nil {
@@ -0,0 +1,212 @@
+package connect
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/airports"
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// Rules decide whether two separately booked awards connect. Times are
+// compared at the connecting airport, using the trips' segment times.
+type Rules struct {
+	MinConnection time.Duration
+	MaxConnection time.Duration // 0 for no limit
+	NextDay       bool          // allow the onward award to leave the local day after arrival
+}
+
+// Gateway is a connection point served from the origin and to the
+// destination, possibly by different programs
+type Gateway struct {
+	Airport  string   `json:"airport"`
+	Distance int      `json:"distance"` // origin to gateway to destination, in miles
+	Detour   float64  `json:"detour"`   // Distance relative to flying direct
+	Inbound  []string `json:"inboundSources"`
+	Outbound []string `json:"outboundSources"`
+}
+
+// Leg is a trip on one of the awards with the airports it flies between
+type Leg struct {
+	api.Trip
+	Origin      string `json:"origin"`
+	Destination string `json:"destination"`
+}
+
+// Itinerary is two separate awards that connect at a gateway
+type Itinerary struct {
+	Via        string `json:"via"`
+	First      Leg    `json:"first"`
+	Second     Leg    `json:"second"`
+	Connection int    `json:"connectionMinutes"`
+	NextDay    bool   `json:"nextDay"`
+	Miles      int    `json:"miles"`
+}
+
+// Gateways finds airports with a route from origin and a route on to
+// destination in the given routes. Gateways whose distance is more than
+// maxDetour times the direct distance are dropped (0 keeps all), and the
+// rest are ordered by detour. Gateways without bundled coordinates can't be
+// measured and sort last.
+func Gateways(routes []api.Route, origin, destination string, maxDetour float64) []Gateway {
+	origin, destination = strings.ToUpper(origin), strings.ToUpper(destination)
+
+	inbound := map[string]map[string]bool{}
+	outbound := map[string]map[string]bool{}
+	for _, r := range routes {
+		from, to := strings.ToUpper(r.OriginAirport), strings.ToUpper(r.DestinationAirport)
+		switch {
+		case from == origin && to != destination:
+			add(inbound, to, r.Source)
+		case to == destination && from != origin:
+			add(outbound, from, r.Source)
+		}
+	}
+
+	direct := distance(origin, destination)
+
+	var gateways []Gateway
+	for via, sources := range inbound {
+		if outbound[via] == nil {
+			continue
+		}
+		g := Gateway{Airport: via, Inbound: sorted(sources), Outbound: sorted(outbound[via])}
+
+		first, second := distance(origin, via), distance(via, destination)
+		if first > 0 && second > 0 {
+			g.Distance = int(math.Round(first + second))
+			if direct > 0 {
+				g.Detour = math.Round((first+second)/direct*100) / 100
+			}
+		}
+		if maxDetour > 0 && g.Detour > maxDetour {
+			continue
+		}
+		gateways = append(gateways, g)
+	}
+
+	sort.Slice(gateways, func(i, j int) bool {
+		a, b := gateways[i], gateways[j]
+		if (a.Detour == 0) != (b.Detour == 0) {
+			return b.Detour == 0
+		}
+		if a.Detour != b.Detour {
+			return a.Detour < b.Detour
+		}
+		return a.Airport < b.Airport
+	})
+	return gateways
+}
+
+// Pair matches each trip on the first leg with the trips on the second leg
+// that leave the airport it lands at within the rules, cheapest first
+func Pair(first, second []Leg, rules Rules) []Itinerary {
+	onward := map[string][]Leg{}
+	for _, out := range second {
+		onward[out.Origin] = append(onward[out.Origin], out)
+	}
+
+	var itineraries []Itinerary
+	for _, in := range first {
+		for _, out := range onward[in.Destination] {
+			if it, ok := connects(in.Destination, in, out, rules); ok {
+				itineraries = append(itineraries, it)
+			}
+		}
+	}
+
+	sort.SliceStable(itineraries, func(i, j int) bool {
+		a, b := itineraries[i], itineraries[j]
+		if a.Miles != b.Miles {
+			return a.Miles < b.Miles
+		}
+		return a.Connection < b.Connection
+	})
+	return itineraries
+}
+
+func connects(via string, in, out Leg, rules Rules) (Itinerary, bool) {
+	arrives, departs := arrival(in.Trip), departure(out.Trip)
+	if arrives.IsZero() || departs.IsZero() {
+		return Itinerary{}, false
+	}
+
+	gap := departs.Sub(arrives)
+	if gap < rules.MinConnection || (rules.MaxConnection > 0 && gap > rules.MaxConnection) {
+		return Itinerary{}, false
+	}
+
+	// Compare calendar days at the connecting airport
+	arriveDay := localDay(via, arrives)
+	departDay := localDay(via, departs)
+	nextDay := departDay.After(arriveDay)
+	if nextDay && (!rules.NextDay || departDay.After(arriveDay.AddDate(0, 0, 1))) {
+		return Itinerary{}, false
+	}
+
+	return Itinerary{
+		Via:        via,
+		First:      in,
+		Second:     out,
+		Connection: int(gap.Minutes()),
+		NextDay:    nextDay,
+		Miles:      in.PartyMiles() + out.PartyMiles(),
+	}, true
+}
+
+// NewLeg places a trip at the endpoints of its segments or, for trips
+// without segment details, of the route it was found on
+func NewLeg(t api.Trip, route api.Route) Leg {
+	if segs := t.AvailabilitySegments; len(segs) > 0 {
+		return Leg{Trip: t, Origin: segs[0].OriginAirport, Destination: segs[len(segs)-1].DestinationAirport}
+	}
+	return Leg{Trip: t, Origin: route.OriginAirport, Destination: route.DestinationAirport}
+}
+
+func arrival(t api.Trip) time.Time {
+	if segs := t.AvailabilitySegments; len(segs) > 0 {
+		return segs[len(segs)-1].ArrivesAt
+	}
+	return t.ArrivesAt
+}
+
+func departure(t api.Trip) time.Time {
+	if segs := t.AvailabilitySegments; len(segs) > 0 {
+		return segs[0].DepartsAt
+	}
+	return t.DepartsAt
+}
+
+func localDay(code string, t time.Time) time.Time {
+	local := airports.Local(code, t)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func distance(from, to string) float64 {
+	a, okA := airports.Lookup(from)
+	b, okB := airports.Lookup(to)
+	if !okA || !okB {
+		return 0
+	}
+	return airports.Distance(a, b)
+}
+
+func add(set map[string]map[string]bool, code, source string) {
+	if set[code] == nil {
+		set[code] = map[string]bool{}
+	}
+	if source != "" {
+		set[code][source] = true
+	}
+}
+
+func sorted(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,107 @@
+package connect
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+func utc(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func leg(from, to, departs, arrives string, miles int) Leg {
+	seg := api.AvailabilitySegment{
+		OriginAirport:      from,
+		DestinationAirport: to,
+		DepartsAt:          utc(departs),
+		ArrivesAt:          utc(arrives),
+	}
+	return NewLeg(api.Trip{AvailabilitySegments: []api.AvailabilitySegment{seg}, MileageCost: miles}, api.Route{})
+}
+
+func TestPair(t *testing.T) {
+	// Lands at YVR at 16:00 PDT on June 1
+	in := leg("SFO", "YVR", "2024-06-01T20:00:00Z", "2024-06-01T23:00:00Z", 10000)
+
+	tests := []struct {
+		name     string
+		out      Leg
+		rules    Rules
+		want     bool
+		wantNext bool
+	}{
+		{"same day", leg("YVR", "NRT", "2024-06-02T01:00:00Z", "2024-06-03T04:00:00Z", 60000), Rules{MinConnection: 2 * time.Hour}, true, false},
+		// 20:00 PDT, the same local day though the next day in UTC
+		{"same local day", leg("YVR", "NRT", "2024-06-02T03:00:00Z", "2024-06-03T06:00:00Z", 60000), Rules{MinConnection: 2 * time.Hour}, true, false},
+		{"under minimum", leg("YVR", "NRT", "2024-06-02T00:30:00Z", "2024-06-03T03:30:00Z", 60000), Rules{MinConnection: 2 * time.Hour}, false, false},
+		{"over maximum", leg("YVR", "NRT", "2024-06-02T03:00:00Z", "2024-06-03T06:00:00Z", 60000), Rules{MinConnection: time.Hour, MaxConnection: 3 * time.Hour}, false, false},
+		{"departs before landing", leg("YVR", "NRT", "2024-06-01T22:00:00Z", "2024-06-03T01:00:00Z", 60000), Rules{}, false, false},
+		// 09:00 PDT on June 2
+		{"next day not allowed", leg("YVR", "NRT", "2024-06-02T16:00:00Z", "2024-06-03T19:00:00Z", 60000), Rules{MinConnection: 2 * time.Hour}, false, false},
+		{"next day allowed", leg("YVR", "NRT", "2024-06-02T16:00:00Z", "2024-06-03T19:00:00Z", 60000), Rules{MinConnection: 2 * time.Hour, NextDay: true}, true, true},
+		// 09:00 PDT on June 3
+		{"two days later", leg("YVR", "NRT", "2024-06-03T16:00:00Z", "2024-06-04T19:00:00Z", 60000), Rules{NextDay: true}, false, false},
+		{"other gateway", leg("SEA", "NRT", "2024-06-02T01:00:00Z", "2024-06-03T04:00:00Z", 60000), Rules{}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Pair([]Leg{in}, []Leg{tt.out}, tt.rules)
+			if !tt.want {
+				if len(got) != 0 {
+					t.Fatalf("Pair = %+v, want no itineraries", got)
+				}
+				return
+			}
+			if len(got) != 1 {
+				t.Fatalf("Pair = %d itineraries, want 1", len(got))
+			}
+			it := got[0]
+			if it.Via != "YVR" || it.NextDay != tt.wantNext || it.Miles != 70000 {
+				t.Errorf("itinerary = via %s, next day %v, %d miles", it.Via, it.NextDay, it.Miles)
+			}
+			if want := int(tt.out.AvailabilitySegments[0].DepartsAt.Sub(utc("2024-06-01T23:00:00Z")).Minutes()); it.Connection != want {
+				t.Errorf("Connection = %d, want %d", it.Connection, want)
+			}
+		})
+	}
+}
+
+func TestPairCheapestFirst(t *testing.T) {
+	in := leg("SFO", "YVR", "2024-06-01T20:00:00Z", "2024-06-01T23:00:00Z", 10000)
+	dear := leg("YVR", "NRT", "2024-06-02T01:00:00Z", "2024-06-03T04:00:00Z", 75000)
+	cheap := leg("YVR", "NRT", "2024-06-02T03:00:00Z", "2024-06-03T06:00:00Z", 60000)
+
+	got := Pair([]Leg{in}, []Leg{dear, cheap}, Rules{MinConnection: 2 * time.Hour})
+	if len(got) != 2 || got[0].Miles != 70000 || got[1].Miles != 85000 {
+		t.Fatalf("Pair = %+v, want the 70k itinerary first", got)
+	}
+}
+
+func TestPairWithoutSegments(t *testing.T) {
+	// Trips without segment details are placed at their search route, not
+	// a parsed route ID
+	in := NewLeg(api.Trip{
+		RouteID:   "route-123",
+		DepartsAt: utc("2024-06-01T20:00:00Z"),
+		ArrivesAt: utc("2024-06-01T23:00:00Z"),
+	}, api.Route{OriginAirport: "SFO", DestinationAirport: "YVR"})
+	out := NewLeg(api.Trip{
+		RouteID:   "route-456",
+		DepartsAt: utc("2024-06-02T01:00:00Z"),
+		ArrivesAt: utc("2024-06-03T04:00:00Z"),
+	}, api.Route{OriginAirport: "YVR", DestinationAirport: "NRT"})
+
+	if in.Origin != "SFO" || in.Destination != "YVR" {
+		t.Errorf("NewLeg = %s-%s, want SFO-YVR", in.Origin, in.Destination)
+	}
+	got := Pair([]Leg{in}, []Leg{out}, Rules{MinConnection: 2 * time.Hour})
+	if len(got) != 1 || got[0].Via != "YVR" || got[0].Connection != 120 {
+		t.Fatalf("Pair = %+v, want one connection at YVR", got)
+	}
+}
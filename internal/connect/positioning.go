@@ -0,0 +1,114 @@
+package connect
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// Alternate is a departure airport near a home base with routes to the
+// destinations, reached with a positioning flight from home
+type Alternate struct {
+	Airport  string   `json:"airport"`
+	Home     string   `json:"home"`     // nearest home airport
+	Distance int      `json:"distance"` // miles from Home
+	Sources  []string `json:"sources"`  // programs flying on to the destinations
+}
+
+// Alternates finds airports within radius miles of a home airport that have
+// a route to one of the destinations, nearest first. Home airports
+// themselves and airports without bundled coordinates are left out.
+func Alternates(routes []api.Route, homes, destinations []string, radius int) []Alternate {
+	isHome := upperSet(homes)
+	isDestination := upperSet(destinations)
+
+	sources := map[string]map[string]bool{}
+	for _, r := range routes {
+		from, to := strings.ToUpper(r.OriginAirport), strings.ToUpper(r.DestinationAirport)
+		if isDestination[to] && !isHome[from] && !isDestination[from] {
+			add(sources, from, r.Source)
+		}
+	}
+
+	var alternates []Alternate
+	for code, programs := range sources {
+		alt := Alternate{Airport: code, Distance: -1, Sources: sorted(programs)}
+		for home := range isHome {
+			d := distance(home, code)
+			if d == 0 {
+				continue
+			}
+			if miles := int(math.Round(d)); alt.Distance < 0 || miles < alt.Distance {
+				alt.Home, alt.Distance = home, miles
+			}
+		}
+		if alt.Distance >= 0 && alt.Distance <= radius {
+			alternates = append(alternates, alt)
+		}
+	}
+
+	sort.Slice(alternates, func(i, j int) bool {
+		a, b := alternates[i], alternates[j]
+		if a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		return a.Airport < b.Airport
+	})
+	return alternates
+}
+
+// Coverage counts the dates that a positioning award can reach in time:
+// space from home on the same day or the day before. It returns how many of
+// dates are covered and the cheapest award in miles across them.
+func Coverage(dates []string, awards []api.Availability) (int, int) {
+	best := map[string]int{} // date -> lowest miles on that date
+	for _, a := range awards {
+		for _, cabin := range api.ValidCabins() {
+			c := a.Cabin(cabin)
+			if !c.Available || c.Miles == 0 {
+				continue
+			}
+			if m, ok := best[a.Date]; !ok || c.Miles < m {
+				best[a.Date] = c.Miles
+			}
+		}
+	}
+
+	covered, lowest := 0, 0
+	for _, date := range dates {
+		found := false
+		for _, day := range []string{date, dayBefore(date)} {
+			if m, ok := best[day]; ok {
+				found = true
+				if lowest == 0 || m < lowest {
+					lowest = m
+				}
+			}
+		}
+		if found {
+			covered++
+		}
+	}
+	return covered, lowest
+}
+
+func dayBefore(date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return ""
+	}
+	return t.AddDate(0, 0, -1).Format("2006-01-02")
+}
+
+func upperSet(codes []string) map[string]bool {
+	set := map[string]bool{}
+	for _, c := range codes {
+		if c = strings.ToUpper(strings.TrimSpace(c)); c != "" {
+			set[c] = true
+		}
+	}
+	return set
+}
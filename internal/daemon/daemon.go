@@ -0,0 +1,304 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/filter"
+	"github.com/JHill6253/seats-aero-cli/internal/history"
+	"github.com/JHill6253/seats-aero-cli/internal/notify"
+	"github.com/JHill6253/seats-aero-cli/internal/saved"
+	"github.com/JHill6253/seats-aero-cli/internal/schedule"
+)
+
+// Options configures a Daemon
+type Options struct {
+	Client   *api.Client
+	Quota    *api.Quota
+	History  *history.Store
+	Searches []saved.Search
+
+	// QuotaFile keeps the daily quota count across restarts; empty to disable
+	QuotaFile string
+
+	// Notifiers resolves notification sink names for a search
+	Notifiers func(names []string) ([]notify.Notifier, error)
+
+	Logger *log.Logger
+}
+
+// job is a scheduled saved search and the state of its last run
+type job struct {
+	search   saved.Search
+	schedule schedule.Schedule
+
+	Name        string     `json:"name"`
+	Schedule    string     `json:"schedule"`
+	NextRun     time.Time  `json:"nextRun"`
+	LastRun     *time.Time `json:"lastRun,omitempty"`
+	LastResults int        `json:"lastResults"`
+	LastChanges int        `json:"lastChanges"`
+	LastError   string     `json:"lastError,omitempty"`
+}
+
+// Daemon runs saved searches on their schedules
+type Daemon struct {
+	opts Options
+
+	mu      sync.Mutex
+	started time.Time
+	jobs    []*job
+}
+
+// New creates a daemon for every saved search that has a schedule
+func New(opts Options) (*Daemon, error) {
+	if opts.Logger == nil {
+		opts.Logger = log.Default()
+	}
+
+	d := &Daemon{opts: opts}
+	for _, search := range opts.Searches {
+		if search.Schedule == "" {
+			continue
+		}
+		sched, err := schedule.Parse(search.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("saved search %q: %w", search.Name, err)
+		}
+		if sched.Next(time.Now()).IsZero() {
+			return nil, fmt.Errorf("saved search %q: schedule %q never runs", search.Name, search.Schedule)
+		}
+		d.jobs = append(d.jobs, &job{
+			search:   search,
+			schedule: sched,
+			Name:     search.Name,
+			Schedule: search.Schedule,
+		})
+	}
+
+	if len(d.jobs) == 0 {
+		return nil, errors.New("no saved searches have a schedule (use seats saved add --schedule)")
+	}
+
+	if err := d.loadQuota(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Run executes jobs as they come due until ctx is cancelled. If runNow is
+// set every job runs once immediately.
+func (d *Daemon) Run(ctx context.Context, runNow bool) error {
+	d.mu.Lock()
+	d.started = time.Now()
+	for _, j := range d.jobs {
+		if runNow {
+			j.NextRun = d.started
+		} else {
+			j.NextRun = j.schedule.Next(d.started)
+		}
+		d.opts.Logger.Printf("scheduled %q (%s), next run %s", j.Name, j.Schedule, j.NextRun.Format(time.RFC3339))
+	}
+	d.mu.Unlock()
+
+	for {
+		next := d.nextDue()
+		timer := time.NewTimer(time.Until(next.NextRun))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			d.opts.Logger.Printf("shutting down")
+			return nil
+		case <-timer.C:
+		}
+
+		d.runJob(next)
+	}
+}
+
+// nextDue returns the job with the earliest next run time
+func (d *Daemon) nextDue() *job {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	next := d.jobs[0]
+	for _, j := range d.jobs[1:] {
+		if j.NextRun.Before(next.NextRun) {
+			next = j
+		}
+	}
+	return next
+}
+
+// runJob runs a saved search, records it in history and notifies on changes
+func (d *Daemon) runJob(j *job) {
+	now := time.Now()
+	results, changes, err := d.execute(j.search, now)
+
+	if qerr := d.saveQuota(); qerr != nil {
+		d.opts.Logger.Printf("saving quota: %v", qerr)
+	}
+
+	d.mu.Lock()
+	j.LastRun = &now
+	j.LastResults = len(results)
+	j.LastChanges = len(changes)
+	j.LastError = ""
+	if err != nil {
+		j.LastError = err.Error()
+	}
+	j.NextRun = j.schedule.Next(now)
+	d.mu.Unlock()
+
+	if err != nil {
+		d.opts.Logger.Printf("%q failed: %v", j.Name, err)
+		return
+	}
+	d.opts.Logger.Printf("%q: %d results, %d changes, next run %s", j.Name, len(results), len(changes), j.NextRun.Format(time.RFC3339))
+}
+
+func (d *Daemon) execute(search saved.Search, now time.Time) ([]api.Availability, []api.Availability, error) {
+	params, err := search.Params(now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := d.opts.Client.Search(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	results := filter.Availability(resp.Data, search.Filters)
+
+	prev, err := d.opts.History.Latest(search.Name)
+	if err != nil {
+		return results, nil, err
+	}
+	if err := d.opts.History.Append(history.Snapshot{Name: search.Name, FetchedAt: now, Results: results}); err != nil {
+		return results, nil, err
+	}
+
+	// The first run establishes a baseline rather than alerting on everything
+	if prev == nil {
+		return results, nil, nil
+	}
+
+	changes := history.Changes(prev.Results, results)
+	if len(changes) > 0 && len(search.Notify) > 0 && d.opts.Notifiers != nil {
+		notifiers, err := d.opts.Notifiers(search.Notify)
+		if err != nil {
+			return results, changes, err
+		}
+		if err := notify.Dispatch(notifiers, notify.ResultsMessage(search.Name, changes)); err != nil {
+			return results, changes, fmt.Errorf("notification failed: %w", err)
+		}
+	}
+
+	return results, changes, nil
+}
+
+// healthStatus is the body served by the health endpoint
+type healthStatus struct {
+	Status   string    `json:"status"`
+	Started  time.Time `json:"started"`
+	Uptime   string    `json:"uptime"`
+	Quota    *quota    `json:"quota,omitempty"`
+	Searches []job     `json:"searches"`
+}
+
+type quota struct {
+	Used  int `json:"used"`
+	Limit int `json:"limit"`
+}
+
+// Handler returns an HTTP handler serving the daemon's health status
+func (d *Daemon) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		d.mu.Lock()
+		status := healthStatus{
+			Status:  "ok",
+			Started: d.started,
+			Uptime:  time.Since(d.started).Round(time.Second).String(),
+		}
+		for _, j := range d.jobs {
+			status.Searches = append(status.Searches, *j)
+		}
+		d.mu.Unlock()
+
+		if d.opts.Quota != nil {
+			status.Quota = &quota{Used: d.opts.Quota.Used(), Limit: d.opts.Quota.Limit()}
+			if remaining := d.opts.Quota.Remaining(); remaining == 0 {
+				status.Status = "quota_exhausted"
+			}
+		}
+		sort.Slice(status.Searches, func(i, k int) bool {
+			return status.Searches[i].NextRun.Before(status.Searches[k].NextRun)
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(status)
+	})
+	return mux
+}
+
+// quotaState is the daily quota count saved in Options.QuotaFile
+type quotaState struct {
+	Day  string `json:"day"`
+	Used int    `json:"used"`
+}
+
+// loadQuota restores today's quota count from a previous run
+func (d *Daemon) loadQuota() error {
+	if d.opts.Quota == nil || d.opts.QuotaFile == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(d.opts.QuotaFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read quota: %w", err)
+	}
+
+	var state quotaState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("failed to parse quota %s: %w", d.opts.QuotaFile, err)
+	}
+	d.opts.Quota.Restore(state.Day, state.Used)
+	return nil
+}
+
+// saveQuota records today's quota count so a restart doesn't reset it
+func (d *Daemon) saveQuota() error {
+	if d.opts.Quota == nil || d.opts.QuotaFile == "" {
+		return nil
+	}
+
+	var state quotaState
+	state.Day, state.Used = d.opts.Quota.State()
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(d.opts.QuotaFile), 0755); err != nil {
+		return fmt.Errorf("failed to create quota directory: %w", err)
+	}
+	if err := os.WriteFile(d.opts.QuotaFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write quota: %w", err)
+	}
+	return nil
+}
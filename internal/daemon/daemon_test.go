@@ -0,0 +1,135 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/history"
+	"github.com/JHill6253/seats-aero-cli/internal/saved"
+)
+
+func testDaemon(t *testing.T, quota *api.Quota, quotaFile string) (*Daemon, *int32) {
+	t.Helper()
+	var calls int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		io.WriteString(w, `{"data":[],"count":0,"hasMore":false}`)
+	}))
+	t.Cleanup(upstream.Close)
+
+	client := api.NewClient("key").WithBaseURL(upstream.URL).WithQuota(quota)
+	d, err := New(Options{
+		Client:    client,
+		Quota:     quota,
+		History:   history.NewStore(t.TempDir()),
+		Searches:  []saved.Search{{Name: "tokyo", From: []string{"SFO"}, To: []string{"NRT"}, Schedule: "@every 1h"}},
+		QuotaFile: quotaFile,
+		Logger:    log.New(io.Discard, "", 0),
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return d, &calls
+}
+
+func TestQuotaPersists(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "quota.json")
+	today := time.Now().Format("2006-01-02")
+	if err := os.WriteFile(file, []byte(`{"day":"`+today+`","used":7}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	quota := api.NewQuota(100)
+	d, calls := testDaemon(t, quota, file)
+	if quota.Used() != 7 {
+		t.Fatalf("restored quota used = %d, want 7", quota.Used())
+	}
+
+	d.runJob(d.jobs[0])
+	if atomic.LoadInt32(calls) != 1 || quota.Used() != 8 {
+		t.Fatalf("after one run: %d upstream calls, quota used %d", atomic.LoadInt32(calls), quota.Used())
+	}
+
+	var state quotaState
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatal(err)
+	}
+	if state.Day != today || state.Used != 8 {
+		t.Errorf("saved quota = %+v, want %s/8", state, today)
+	}
+}
+
+func TestQuotaFromEarlierDayIgnored(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "quota.json")
+	if err := os.WriteFile(file, []byte(`{"day":"2000-01-01","used":50}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	quota := api.NewQuota(100)
+	testDaemon(t, quota, file)
+	if quota.Used() != 0 {
+		t.Errorf("quota used = %d, want a stale day to be ignored", quota.Used())
+	}
+}
+
+func TestHealthDuringRun(t *testing.T) {
+	quota := api.NewQuota(100)
+	d, calls := testDaemon(t, quota, "")
+	health := httptest.NewServer(d.Handler())
+	defer health.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- d.Run(ctx, true) }()
+
+	// Poll until the immediate run is recorded, reading health concurrently
+	var status healthStatus
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		resp, err := http.Get(health.URL + "/healthz")
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = json.NewDecoder(resp.Body).Decode(&status)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(status.Searches) == 1 && status.Searches[0].LastRun != nil {
+			break
+		}
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if status.Started.IsZero() || status.Quota == nil || status.Quota.Used != 1 {
+		t.Errorf("status = %+v", status)
+	}
+	if len(status.Searches) != 1 || status.Searches[0].LastRun == nil || atomic.LoadInt32(calls) != 1 {
+		t.Errorf("search did not run once: %+v", status.Searches)
+	}
+}
+
+func TestNewRequiresSchedule(t *testing.T) {
+	if _, err := New(Options{Searches: []saved.Search{{Name: "x"}}}); err == nil {
+		t.Error("New without scheduled searches succeeded")
+	}
+	if _, err := New(Options{Searches: []saved.Search{{Name: "x", Schedule: "0 0 31 2 *"}}}); err == nil {
+		t.Error("New with a schedule that never runs succeeded")
+	}
+}
@@ -0,0 +1,115 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/calendar"
+)
+
+// CalendarToJSON exports a calendar heatmap as JSON
+func CalendarToJSON(w io.Writer, cal calendar.Calendar, pretty bool) error {
+	encoder := json.NewEncoder(w)
+	if pretty {
+		encoder.SetIndent("", "  ")
+	}
+	return encoder.Encode(cal)
+}
+
+// calendarCell is a single grid cell; blank cells pad the first and last weeks
+type calendarCell struct {
+	Blank bool
+	Num   int
+	calendar.Day
+}
+
+var calendarTemplate = template.Must(template.New("calendar").Funcs(template.FuncMap{
+	"cabin":  api.CabinDisplayName,
+	"source": api.SourceDisplayName,
+	"join":   strings.Join,
+	"k": func(miles int) string {
+		if miles%1000 == 0 {
+			return fmt.Sprintf("%dk", miles/1000)
+		}
+		return fmt.Sprintf("%.1fk", float64(miles)/1000)
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{join .Cal.Origins ","}} → {{join .Cal.Destinations ","}} · {{cabin .Cal.Cabin}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 2rem; color: #111827; }
+h1 { color: #7C3AED; font-size: 1.4rem; }
+.months { display: flex; flex-wrap: wrap; gap: 2rem; }
+table { border-collapse: collapse; }
+caption { font-weight: bold; padding-bottom: .5rem; }
+th { font-size: .75rem; color: #6B7280; padding: .25rem; }
+td { width: 4.5rem; height: 3.2rem; vertical-align: top; border: 1px solid #E5E7EB; padding: .25rem; font-size: .75rem; }
+td .day { color: #6B7280; }
+td .miles { font-weight: bold; font-size: .9rem; }
+.l0 { background: #F9FAFB; }
+.l1 { background: #BBF7D0; }
+.l2 { background: #FEF08A; }
+.l3 { background: #FECACA; }
+.legend span { display: inline-block; padding: .2rem .6rem; margin-right: .5rem; border: 1px solid #E5E7EB; }
+</style>
+</head>
+<body>
+<h1>{{join .Cal.Origins ","}} → {{join .Cal.Destinations ","}} · {{cabin .Cal.Cabin}}</h1>
+<p class="legend">
+<span class="l1">cheapest</span><span class="l2">mid</span><span class="l3">highest</span><span class="l0">none</span>
+{{if .Cal.MinMiles}}Range {{k .Cal.MinMiles}} – {{k .Cal.MaxMiles}} miles{{end}}
+</p>
+<div class="months">
+{{range .Grids}}
+<table>
+<caption>{{.Name}}</caption>
+<tr><th>Sun</th><th>Mon</th><th>Tue</th><th>Wed</th><th>Thu</th><th>Fri</th><th>Sat</th></tr>
+{{range .Weeks}}<tr>{{range .}}{{if .Blank}}<td></td>{{else}}<td class="l{{.Level}}"{{if .Available}} title="{{source .Source}} {{.Route}}{{if .Direct}} (direct){{end}}"{{end}}><div class="day">{{.Num}}</div>{{if .Available}}<div class="miles">{{k .Miles}}</div><div>{{.Seats}} seats</div>{{end}}</td>{{end}}{{end}}</tr>
+{{end}}</table>
+{{end}}
+</div>
+</body>
+</html>
+`))
+
+type calendarGrid struct {
+	Name  string
+	Weeks [][]calendarCell
+}
+
+// CalendarToHTML exports a calendar heatmap as a self-contained HTML page
+func CalendarToHTML(w io.Writer, cal calendar.Calendar) error {
+	grids := make([]calendarGrid, 0, len(cal.Months))
+	for _, m := range cal.Months {
+		grid := calendarGrid{Name: m.Name}
+		week := make([]calendarCell, 0, 7)
+		for i := 0; i < int(m.FirstWeekday()); i++ {
+			week = append(week, calendarCell{Blank: true})
+		}
+		for i, day := range m.Days {
+			week = append(week, calendarCell{Num: i + 1, Day: day})
+			if len(week) == 7 {
+				grid.Weeks = append(grid.Weeks, week)
+				week = make([]calendarCell, 0, 7)
+			}
+		}
+		if len(week) > 0 {
+			for len(week) < 7 {
+				week = append(week, calendarCell{Blank: true})
+			}
+			grid.Weeks = append(grid.Weeks, week)
+		}
+		grids = append(grids, grid)
+	}
+
+	return calendarTemplate.Execute(w, struct {
+		Cal   calendar.Calendar
+		Grids []calendarGrid
+	}{cal, grids})
+}
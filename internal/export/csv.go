@@ -6,10 +6,18 @@ import (
 	"strconv"
 
 	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/valuation"
 )
 
-// ToCSV exports availability data as CSV
-func ToCSV(w io.Writer, data []api.Availability) error {
+// Column is an extra CSV column computed for each availability
+type Column struct {
+	Name  string
+	Value func(a api.Availability) string
+}
+
+// ToCSV exports availability data as CSV, with the effective cost in USD of
+// each cabin estimated from vals and any extra columns appended
+func ToCSV(w io.Writer, data []api.Availability, vals valuation.Table, extra ...Column) error {
 	writer := csv.NewWriter(w)
 	defer writer.Flush()
 
@@ -36,6 +44,13 @@ func ToCSV(w io.Writer, data []api.Availability) error {
 		"F_Miles",
 		"F_Seats",
 		"F_Direct",
+		"Y_Value",
+		"W_Value",
+		"J_Value",
+		"F_Value",
+	}
+	for _, c := range extra {
+		header = append(header, c.Name)
 	}
 	if err := writer.Write(header); err != nil {
 		return err
@@ -49,22 +64,22 @@ func ToCSV(w io.Writer, data []api.Availability) error {
 			a.Route.OriginAirport,
 			a.Route.DestinationAirport,
 			a.Source,
-			strconv.FormatBool(a.YAvailable),
-			a.YMileageCost,
-			strconv.Itoa(a.YRemainingSeats),
-			strconv.FormatBool(a.YDirect),
-			strconv.FormatBool(a.WAvailable),
-			a.WMileageCost,
-			strconv.Itoa(a.WRemainingSeats),
-			strconv.FormatBool(a.WDirect),
-			strconv.FormatBool(a.JAvailable),
-			a.JMileageCost,
-			strconv.Itoa(a.JRemainingSeats),
-			strconv.FormatBool(a.JDirect),
-			strconv.FormatBool(a.FAvailable),
-			a.FMileageCost,
-			strconv.Itoa(a.FRemainingSeats),
-			strconv.FormatBool(a.FDirect),
+		}
+		// Miles come from the cabin view so they are priced for the party
+		for _, cabin := range api.ValidCabins() {
+			c := a.Cabin(cabin)
+			row = append(row,
+				strconv.FormatBool(c.Available),
+				c.MileageCost,
+				strconv.Itoa(c.RemainingSeats),
+				strconv.FormatBool(c.Direct),
+			)
+		}
+		for _, cabin := range api.ValidCabins() {
+			row = append(row, cabinValue(vals, a, cabin))
+		}
+		for _, c := range extra {
+			row = append(row, c.Value(a))
 		}
 		if err := writer.Write(row); err != nil {
 			return err
@@ -74,8 +89,9 @@ func ToCSV(w io.Writer, data []api.Availability) error {
 	return nil
 }
 
-// TripsToCSV exports trip data as CSV
-func TripsToCSV(w io.Writer, data []api.Trip) error {
+// TripsToCSV exports trip data as CSV, with the effective cost in USD
+// estimated from vals
+func TripsToCSV(w io.Writer, data []api.Trip, vals valuation.Table) error {
 	writer := csv.NewWriter(w)
 	defer writer.Flush()
 
@@ -93,6 +109,7 @@ func TripsToCSV(w io.Writer, data []api.Trip) error {
 		"Departs",
 		"Arrives",
 		"Source",
+		"Value",
 	}
 	if err := writer.Write(header); err != nil {
 		return err
@@ -107,12 +124,13 @@ func TripsToCSV(w io.Writer, data []api.Trip) error {
 			t.FlightNumbers,
 			strconv.Itoa(t.Stops),
 			strconv.Itoa(t.TotalDuration),
-			strconv.Itoa(t.MileageCost),
-			strconv.Itoa(t.TotalTaxes),
+			strconv.Itoa(t.PartyMiles()),
+			strconv.Itoa(t.PartyTaxes()),
 			strconv.Itoa(t.RemainingSeats),
 			t.DepartsAt.Format("2006-01-02 15:04"),
 			t.ArrivesAt.Format("2006-01-02 15:04"),
 			t.Source,
+			formatValue(vals.Trip(t).Cost),
 		}
 		if err := writer.Write(row); err != nil {
 			return err
@@ -155,3 +173,15 @@ func RoutesToCSV(w io.Writer, data []api.Route) error {
 
 	return nil
 }
+
+func cabinValue(vals valuation.Table, a api.Availability, cabin string) string {
+	v, ok := vals.Cabin(a, cabin)
+	if !ok {
+		return ""
+	}
+	return formatValue(v.Cost)
+}
+
+func formatValue(cost float64) string {
+	return strconv.FormatFloat(cost, 'f', 2, 64)
+}
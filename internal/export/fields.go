@@ -0,0 +1,171 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/valuation"
+)
+
+// Field is a selectable availability column
+type Field struct {
+	Name  string
+	value func(a api.Availability, vals valuation.Table) interface{}
+}
+
+// Value returns the field's value for an availability
+func (f Field) Value(a api.Availability, vals valuation.Table) interface{} {
+	return f.value(a, vals)
+}
+
+// Text returns the field's value formatted for tables and CSV
+func (f Field) Text(a api.Availability, vals valuation.Table) string {
+	switch v := f.value(a, vals).(type) {
+	case float64:
+		return fmt.Sprintf("%.2f", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// routeFields are the fields not tied to a cabin
+var routeFields = map[string]func(a api.Availability, vals valuation.Table) interface{}{
+	"id":          func(a api.Availability, _ valuation.Table) interface{} { return a.ID },
+	"date":        func(a api.Availability, _ valuation.Table) interface{} { return a.Date },
+	"origin":      func(a api.Availability, _ valuation.Table) interface{} { return a.Route.OriginAirport },
+	"dest":        func(a api.Availability, _ valuation.Table) interface{} { return a.Route.DestinationAirport },
+	"destination": func(a api.Availability, _ valuation.Table) interface{} { return a.Route.DestinationAirport },
+	"route": func(a api.Availability, _ valuation.Table) interface{} {
+		return a.Route.OriginAirport + "-" + a.Route.DestinationAirport
+	},
+	"distance": func(a api.Availability, _ valuation.Table) interface{} { return a.Route.Distance },
+	"source":   func(a api.Availability, _ valuation.Table) interface{} { return a.Source },
+	"program":  func(a api.Availability, _ valuation.Table) interface{} { return api.SourceDisplayName(a.Source) },
+}
+
+// cabinFields are the per-cabin fields, selected as e.g. J.miles
+var cabinFields = map[string]func(c api.CabinAvailability, a api.Availability, vals valuation.Table) interface{}{
+	"available": func(c api.CabinAvailability, _ api.Availability, _ valuation.Table) interface{} { return c.Available },
+	"miles":     func(c api.CabinAvailability, _ api.Availability, _ valuation.Table) interface{} { return c.Miles },
+	"seats": func(c api.CabinAvailability, _ api.Availability, _ valuation.Table) interface{} {
+		return c.RemainingSeats
+	},
+	"direct":   func(c api.CabinAvailability, _ api.Availability, _ valuation.Table) interface{} { return c.Direct },
+	"airlines": func(c api.CabinAvailability, _ api.Availability, _ valuation.Table) interface{} { return c.Airlines },
+	"taxes": func(c api.CabinAvailability, a api.Availability, _ valuation.Table) interface{} {
+		return api.TaxesAmount(c.TotalTaxes, a.TaxesCurrency)
+	},
+	"value": func(c api.CabinAvailability, a api.Availability, vals valuation.Table) interface{} {
+		v, ok := vals.Cabin(a, c.Cabin)
+		if !ok {
+			return 0.0
+		}
+		return v.Cost
+	},
+}
+
+// ParseFields parses a --fields list such as "date,origin,dest,J.miles"
+func ParseFields(spec string) ([]Field, error) {
+	var fields []Field
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		if fn, ok := routeFields[strings.ToLower(name)]; ok {
+			fields = append(fields, Field{Name: strings.ToLower(name), value: fn})
+			continue
+		}
+
+		cabin, attr, ok := strings.Cut(name, ".")
+		cabin = strings.ToUpper(cabin)
+		attr = strings.ToLower(attr)
+		fn, known := cabinFields[attr]
+		if !ok || !known || !isCabinCode(cabin) {
+			return nil, fmt.Errorf("unknown field %q (see --help for the available fields)", name)
+		}
+		fields = append(fields, Field{
+			Name: cabin + "." + attr,
+			value: func(a api.Availability, vals valuation.Table) interface{} {
+				return fn(a.Cabin(cabin), a, vals)
+			},
+		})
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields selected")
+	}
+	return fields, nil
+}
+
+// FieldNames lists the selectable field names for help text
+func FieldNames() string {
+	return "id, date, origin, dest, route, distance, source, program, and per cabin " +
+		"<Y|W|J|F>.available, .miles, .seats, .direct, .airlines, .taxes, .value"
+}
+
+func isCabinCode(code string) bool {
+	for _, c := range api.ValidCabins() {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldsToCSV exports the selected fields of each availability as CSV
+func FieldsToCSV(w io.Writer, data []api.Availability, fields []Field, vals valuation.Table) error {
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	header := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = f.Name
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, a := range data {
+		row := make([]string, len(fields))
+		for i, f := range fields {
+			row[i] = f.Text(a, vals)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FieldsToJSON exports the selected fields of each availability as an array
+// of objects, keeping the fields in the order they were selected
+func FieldsToJSON(w io.Writer, data []api.Availability, fields []Field, vals valuation.Table, pretty bool) error {
+	records := make([]json.RawMessage, len(data))
+	for i, a := range data {
+		var b bytes.Buffer
+		b.WriteByte('{')
+		for j, f := range fields {
+			if j > 0 {
+				b.WriteByte(',')
+			}
+			key, _ := json.Marshal(f.Name)
+			value, err := json.Marshal(f.Value(a, vals))
+			if err != nil {
+				return err
+			}
+			b.Write(key)
+			b.WriteByte(':')
+			b.Write(value)
+		}
+		b.WriteByte('}')
+		records[i] = b.Bytes()
+	}
+	return WriteJSON(w, records, pretty)
+}
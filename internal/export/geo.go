@@ -0,0 +1,309 @@
+package export
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/JHill6253/seats-aero-cli/internal/airports"
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/valuation"
+)
+
+// MapLine is a route drawn between two bundled airports, with the properties
+// shown on the map
+type MapLine struct {
+	Origin      airports.Airport
+	Destination airports.Airport
+	Source      string
+	Distance    int    // miles, from the API or computed from coordinates
+	BestCabin   string // cabin code of the cheapest option, if any
+	BestMiles   int
+	BestValue   float64 // effective cost in USD of the cheapest option
+	BestDate    string
+	Dates       int // availability dates aggregated into the line
+}
+
+// RouteLines builds map lines for routes. Routes with an airport that isn't
+// bundled can't be placed and are returned as missing codes.
+func RouteLines(routes []api.Route) ([]MapLine, []string) {
+	var lines []MapLine
+	missing := map[string]bool{}
+	for _, r := range routes {
+		from, to, ok := lineAirports(r.OriginAirport, r.DestinationAirport, missing)
+		if !ok {
+			continue
+		}
+		lines = append(lines, MapLine{
+			Origin:      from,
+			Destination: to,
+			Source:      r.Source,
+			Distance:    lineDistance(r.Distance, from, to),
+		})
+	}
+	return lines, sortedKeys(missing)
+}
+
+// AvailabilityLines builds one map line per route and program, carrying the
+// cheapest option across its dates and cabins
+func AvailabilityLines(data []api.Availability, vals valuation.Table) ([]MapLine, []string) {
+	var lines []MapLine
+	index := map[string]int{}
+	missing := map[string]bool{}
+
+	for _, a := range data {
+		from, to, ok := lineAirports(a.Route.OriginAirport, a.Route.DestinationAirport, missing)
+		if !ok {
+			continue
+		}
+
+		key := from.IATA + "-" + to.IATA + "-" + a.Source
+		i, seen := index[key]
+		if !seen {
+			i = len(lines)
+			index[key] = i
+			lines = append(lines, MapLine{
+				Origin:      from,
+				Destination: to,
+				Source:      a.Source,
+				Distance:    lineDistance(a.Route.Distance, from, to),
+			})
+		}
+
+		line := &lines[i]
+		line.Dates++
+		v, cabin, ok := vals.Best(a)
+		if ok && (line.BestCabin == "" || v.Cost < line.BestValue) {
+			line.BestCabin = cabin
+			line.BestMiles = v.Miles
+			line.BestValue = v.Cost
+			line.BestDate = a.Date
+		}
+	}
+	return lines, sortedKeys(missing)
+}
+
+func lineAirports(origin, destination string, missing map[string]bool) (airports.Airport, airports.Airport, bool) {
+	from, okFrom := airports.Lookup(origin)
+	to, okTo := airports.Lookup(destination)
+	if !okFrom {
+		missing[origin] = true
+	}
+	if !okTo {
+		missing[destination] = true
+	}
+	return from, to, okFrom && okTo
+}
+
+func lineDistance(distance int, from, to airports.Airport) int {
+	if distance > 0 {
+		return distance
+	}
+	return int(math.Round(airports.Distance(from, to)))
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// linePoints picks enough points for a smooth curve, about one per 100 miles
+func linePoints(l MapLine) [][2]float64 {
+	n := l.Distance/100 + 2
+	if n > 128 {
+		n = 128
+	}
+	return airports.GreatCircle(l.Origin, l.Destination, n)
+}
+
+// properties are the attributes attached to each line
+func (l MapLine) properties() [][2]interface{} {
+	props := [][2]interface{}{
+		{"name", l.Origin.IATA + "-" + l.Destination.IATA},
+		{"origin", l.Origin.IATA},
+		{"destination", l.Destination.IATA},
+		{"source", l.Source},
+		{"program", api.SourceDisplayName(l.Source)},
+		{"distance", l.Distance},
+	}
+	if l.Dates > 0 {
+		props = append(props, [2]interface{}{"dates", l.Dates})
+	}
+	if l.BestCabin != "" {
+		props = append(props,
+			[2]interface{}{"bestCabin", api.CabinDisplayName(l.BestCabin)},
+			[2]interface{}{"bestMiles", l.BestMiles},
+			[2]interface{}{"bestValue", math.Round(l.BestValue*100) / 100},
+			[2]interface{}{"bestDate", l.BestDate},
+		)
+	}
+	return props
+}
+
+type geoJSONCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   geoJSONGeometry        `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// LinesToGeoJSON exports map lines as a GeoJSON FeatureCollection of
+// great-circle lines plus a point for each airport. Lines that cross the
+// antimeridian are split into a MultiLineString, as RFC 7946 recommends.
+func LinesToGeoJSON(w io.Writer, lines []MapLine) error {
+	collection := geoJSONCollection{Type: "FeatureCollection", Features: []geoJSONFeature{}}
+
+	seen := map[string]bool{}
+	var points []airports.Airport
+	for _, l := range lines {
+		props := map[string]interface{}{}
+		for _, p := range l.properties() {
+			props[p[0].(string)] = p[1]
+		}
+
+		geometry := geoJSONGeometry{Type: "LineString"}
+		if parts := splitAntimeridian(linePoints(l)); len(parts) == 1 {
+			geometry.Coordinates = parts[0]
+		} else {
+			geometry.Type = "MultiLineString"
+			geometry.Coordinates = parts
+		}
+		collection.Features = append(collection.Features, geoJSONFeature{Type: "Feature", Geometry: geometry, Properties: props})
+
+		for _, a := range []airports.Airport{l.Origin, l.Destination} {
+			if !seen[a.IATA] {
+				seen[a.IATA] = true
+				points = append(points, a)
+			}
+		}
+	}
+
+	for _, a := range points {
+		collection.Features = append(collection.Features, geoJSONFeature{
+			Type:     "Feature",
+			Geometry: geoJSONGeometry{Type: "Point", Coordinates: [2]float64{a.Lon, a.Lat}},
+			Properties: map[string]interface{}{
+				"name":    a.IATA,
+				"airport": a.Name,
+				"city":    a.City,
+				"country": a.Country,
+			},
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(collection)
+}
+
+// splitAntimeridian breaks a path where it jumps across the ±180° meridian,
+// adding the crossing point to both sides
+func splitAntimeridian(points [][2]float64) [][][2]float64 {
+	parts := [][][2]float64{{points[0]}}
+	for i := 1; i < len(points); i++ {
+		prev, cur := points[i-1], points[i]
+		if math.Abs(cur[0]-prev[0]) <= 180 {
+			parts[len(parts)-1] = append(parts[len(parts)-1], cur)
+			continue
+		}
+
+		// Unwrap the current longitude to find where the segment hits the edge
+		edge := 180.0
+		if prev[0] < 0 {
+			edge = -180
+		}
+		unwrapped := cur[0] + 2*edge
+		f := (edge - prev[0]) / (unwrapped - prev[0])
+		lat := prev[1] + f*(cur[1]-prev[1])
+
+		parts[len(parts)-1] = append(parts[len(parts)-1], [2]float64{edge, lat})
+		parts = append(parts, [][2]float64{{-edge, lat}, cur})
+	}
+	return parts
+}
+
+// kmlColors are line colors per best cabin in KML's aabbggrr order
+var kmlColors = map[string]string{
+	"":  "ff9ca3af",
+	"Y": "ff5ec522",
+	"W": "fff68231",
+	"J": "ffed3a7c",
+	"F": "ff0b9ef5",
+}
+
+// LinesToKML exports map lines as a KML document with a placemark per line,
+// colored by the cheapest cabin
+func LinesToKML(w io.Writer, name string, lines []MapLine) error {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<kml xmlns="http://www.opengis.net/kml/2.2">` + "\n<Document>\n")
+	fmt.Fprintf(&b, "<name>%s</name>\n", kmlEscape(name))
+
+	codes := []string{""}
+	codes = append(codes, api.ValidCabins()...)
+	for _, code := range codes {
+		fmt.Fprintf(&b, "<Style id=\"%s\"><LineStyle><color>%s</color><width>2</width></LineStyle></Style>\n", kmlStyle(code), kmlColors[code])
+	}
+
+	for _, l := range lines {
+		b.WriteString("<Placemark>\n")
+		fmt.Fprintf(&b, "<name>%s</name>\n", kmlEscape(l.Origin.IATA+"-"+l.Destination.IATA))
+		fmt.Fprintf(&b, "<description>%s</description>\n", kmlEscape(kmlDescription(l)))
+		fmt.Fprintf(&b, "<styleUrl>#%s</styleUrl>\n", kmlStyle(l.BestCabin))
+		b.WriteString("<ExtendedData>\n")
+		for _, p := range l.properties() {
+			fmt.Fprintf(&b, "<Data name=\"%s\"><value>%s</value></Data>\n", p[0], kmlEscape(fmt.Sprint(p[1])))
+		}
+		b.WriteString("</ExtendedData>\n")
+		b.WriteString("<LineString><tessellate>1</tessellate><coordinates>")
+		for i, p := range linePoints(l) {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			fmt.Fprintf(&b, "%.5f,%.5f,0", p[0], p[1])
+		}
+		b.WriteString("</coordinates></LineString>\n</Placemark>\n")
+	}
+
+	b.WriteString("</Document>\n</kml>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func kmlStyle(cabin string) string {
+	if cabin == "" {
+		return "route"
+	}
+	return "cabin-" + cabin
+}
+
+func kmlDescription(l MapLine) string {
+	desc := fmt.Sprintf("%s → %s · %s · %s mi", l.Origin.City, l.Destination.City, api.SourceDisplayName(l.Source), formatThousands(l.Distance))
+	if l.BestCabin != "" {
+		desc += fmt.Sprintf(" · best %s %s miles on %s", api.CabinDisplayName(l.BestCabin), formatThousands(l.BestMiles), l.BestDate)
+	}
+	return desc
+}
+
+func kmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,374 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/airports"
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+const icsTimeFormat = "20060102T150405"
+
+// TripsToICS exports trips as an iCalendar file with one VEVENT per flight
+// segment. Times are written in each airport's local time zone, with a
+// VTIMEZONE definition for every zone used. Trips without segment details
+// become one event, placed at the route of their availability in avail when
+// it is there.
+func TripsToICS(w io.Writer, data []api.Trip, avail []api.Availability) error {
+	ics := &icsWriter{w: w}
+	stamp := time.Now().UTC().Format(icsTimeFormat) + "Z"
+
+	ics.line("BEGIN:VCALENDAR")
+	ics.line("VERSION:2.0")
+	ics.line("PRODID:-//seats-aero-cli//seats//EN")
+	ics.line("CALSCALE:GREGORIAN")
+	ics.line("METHOD:PUBLISH")
+
+	events := icsEvents(data, avail)
+	for _, tz := range icsZones(events) {
+		ics.timezone(tz.name, tz.years)
+	}
+
+	for _, e := range events {
+		ics.line("BEGIN:VEVENT")
+		ics.line("UID:" + e.uid)
+		ics.line("DTSTAMP:" + stamp)
+		ics.line(icsDate("DTSTART", e.origin, e.departs))
+		ics.line(icsDate("DTEND", e.destination, e.arrives))
+		ics.line("SUMMARY:" + icsEscape(e.summary))
+		if e.location != "" {
+			ics.line("LOCATION:" + icsEscape(e.location))
+		}
+		ics.line("DESCRIPTION:" + icsEscape(e.description))
+		ics.line("TRANSP:OPAQUE")
+		ics.line("END:VEVENT")
+	}
+
+	ics.line("END:VCALENDAR")
+	return ics.err
+}
+
+type icsEvent struct {
+	uid, summary, location, description string
+	origin, destination                 string
+	departs, arrives                    time.Time
+}
+
+func icsEvents(data []api.Trip, avail []api.Availability) []icsEvent {
+	routes := availabilityRoutes(avail)
+	var events []icsEvent
+	for _, t := range data {
+		segs := t.AvailabilitySegments
+		if len(segs) == 0 {
+			// Fall back to a single event spanning the whole trip
+			origin, destination := tripEndpoints(t, routes)
+			segs = []api.AvailabilitySegment{{
+				FlightNumber:       t.FlightNumbers,
+				OriginAirport:      origin,
+				DestinationAirport: destination,
+				DepartsAt:          t.DepartsAt,
+				ArrivesAt:          t.ArrivesAt,
+			}}
+		}
+
+		for i, seg := range segs {
+			summary := seg.FlightNumber
+			if route := icsRoute(seg.OriginAirport, seg.DestinationAirport); route != "" {
+				summary += " " + route
+			}
+			events = append(events, icsEvent{
+				uid:         fmt.Sprintf("%s-%d@seats.aero", t.ID, i+1),
+				summary:     summary,
+				location:    airportLabel(seg.OriginAirport),
+				description: icsDescription(t, seg, i, len(segs)),
+				origin:      seg.OriginAirport,
+				destination: seg.DestinationAirport,
+				departs:     seg.DepartsAt,
+				arrives:     seg.ArrivesAt,
+			})
+		}
+	}
+	return events
+}
+
+// availabilityRoutes indexes the routes of availabilities by their ID
+func availabilityRoutes(avail []api.Availability) map[string]api.Route {
+	routes := make(map[string]api.Route, len(avail))
+	for _, a := range avail {
+		routes[a.ID] = a.Route
+	}
+	return routes
+}
+
+// tripEndpoints returns the origin and destination of a trip from its
+// segments, or from the route of its availability when segment details are
+// missing. Both are empty when neither is known.
+func tripEndpoints(t api.Trip, routes map[string]api.Route) (string, string) {
+	if segs := t.AvailabilitySegments; len(segs) > 0 {
+		return segs[0].OriginAirport, segs[len(segs)-1].DestinationAirport
+	}
+	r := routes[t.AvailabilityID]
+	return r.OriginAirport, r.DestinationAirport
+}
+
+func icsDescription(t api.Trip, seg api.AvailabilitySegment, i, n int) string {
+	lines := []string{"Flight " + seg.FlightNumber}
+	if n > 1 {
+		lines[0] += fmt.Sprintf(" (segment %d of %d)", i+1, n)
+	}
+	if route := icsRoute(airportLabel(seg.OriginAirport), airportLabel(seg.DestinationAirport)); route != "" {
+		lines = append(lines, route)
+	}
+	if seg.AircraftName != "" || seg.AircraftCode != "" {
+		lines = append(lines, "Aircraft: "+strings.TrimSpace(seg.AircraftName+" "+parenthesize(seg.AircraftCode)))
+	}
+	cabin := t.Cabin
+	if seg.FareClass != "" {
+		cabin += fmt.Sprintf(" (fare class %s)", seg.FareClass)
+	}
+	lines = append(lines, "Cabin: "+cabin)
+	if t.Source != "" {
+		lines = append(lines, "Program: "+api.SourceDisplayName(t.Source))
+	}
+	if t.PartyMiles() > 0 {
+		cost := fmt.Sprintf("%s miles", formatThousands(t.PartyMiles()))
+		if t.PartyTaxes() > 0 {
+			cost += " + " + t.TaxesCurrencySymbol + api.FormatTaxes(t.PartyTaxes(), t.TaxesCurrency)
+			if t.TaxesCurrencySymbol == "" && t.TaxesCurrency != "" {
+				cost += " " + t.TaxesCurrency
+			}
+		}
+		lines = append(lines, "Cost: "+cost)
+	}
+	lines = append(lines, "Trip ID: "+t.ID)
+	return strings.Join(lines, "\n")
+}
+
+// icsRoute joins two airports with an arrow, or is empty when both are unknown
+func icsRoute(from, to string) string {
+	if from == "" && to == "" {
+		return ""
+	}
+	return from + " → " + to
+}
+
+func airportLabel(code string) string {
+	if a, ok := airports.Lookup(code); ok {
+		return fmt.Sprintf("%s (%s)", a.Name, code)
+	}
+	return code
+}
+
+func parenthesize(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "(" + s + ")"
+}
+
+// formatThousands formats n with comma separators
+func formatThousands(n int) string {
+	if n < 0 {
+		return "-" + formatThousands(-n)
+	}
+	s := fmt.Sprintf("%d", n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// UnzonedAirports lists the airports of the trips without a bundled time
+// zone, whose calendar times are written in UTC
+func UnzonedAirports(data []api.Trip, avail []api.Availability) []string {
+	missing := map[string]bool{}
+	for _, e := range icsEvents(data, avail) {
+		for _, code := range []string{e.origin, e.destination} {
+			if code != "" && !airports.HasTimeZone(code) {
+				missing[strings.ToUpper(code)] = true
+			}
+		}
+	}
+	return sortedKeys(missing)
+}
+
+// icsDate formats a date-time property in the airport's time zone, falling
+// back to UTC for airports without a known zone
+func icsDate(name, airport string, t time.Time) string {
+	if !airports.HasTimeZone(airport) {
+		return name + ":" + t.UTC().Format(icsTimeFormat) + "Z"
+	}
+	loc := airports.Location(airport)
+	return fmt.Sprintf("%s;TZID=%s:%s", name, loc.String(), t.In(loc).Format(icsTimeFormat))
+}
+
+type icsZone struct {
+	name  string
+	years []int
+}
+
+// icsZones lists the time zones and years referenced by the events
+func icsZones(events []icsEvent) []icsZone {
+	years := map[string]map[int]bool{}
+	add := func(airport string, t time.Time) {
+		if !airports.HasTimeZone(airport) {
+			return
+		}
+		loc := airports.Location(airport)
+		if years[loc.String()] == nil {
+			years[loc.String()] = map[int]bool{}
+		}
+		years[loc.String()][t.In(loc).Year()] = true
+	}
+	for _, e := range events {
+		add(e.origin, e.departs)
+		add(e.destination, e.arrives)
+	}
+
+	zones := make([]icsZone, 0, len(years))
+	for name, ys := range years {
+		z := icsZone{name: name}
+		for y := range ys {
+			z.years = append(z.years, y)
+		}
+		sort.Ints(z.years)
+		zones = append(zones, z)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].name < zones[j].name })
+	return zones
+}
+
+// icsTransition is a change in UTC offset
+type icsTransition struct {
+	at         time.Time
+	from, to   int
+	abbrev     string
+	isDaylight bool
+}
+
+// timezone writes a VTIMEZONE for a zone with every offset change from the
+// year before the first exported year through the last, so each exported
+// date follows a real transition. A zone that kept one offset over the year
+// before gets a single observance for that offset instead.
+func (ics *icsWriter) timezone(name string, years []int) {
+	loc, err := time.LoadLocation(name)
+	if err != nil || len(years) == 0 {
+		return
+	}
+	start := time.Date(years[0]-1, time.January, 1, 0, 0, 0, 0, loc)
+	end := time.Date(years[len(years)-1]+1, time.January, 1, 0, 0, 0, 0, loc)
+	transitions := zoneTransitions(start, end)
+
+	ics.line("BEGIN:VTIMEZONE")
+	ics.line("TZID:" + name)
+	if len(transitions) == 0 || transitions[0].at.Year() > start.Year() {
+		abbrev, offset := start.Zone()
+		ics.observance("19700101T000000", icsTransition{
+			from: offset, to: offset, abbrev: abbrev,
+			isDaylight: offset > standardOffset(loc, start.Year()),
+		})
+	}
+	for _, tr := range transitions {
+		// DTSTART of an observance is the local time before the change
+		ics.observance(tr.at.UTC().Add(time.Duration(tr.from)*time.Second).Format(icsTimeFormat), tr)
+	}
+	ics.line("END:VTIMEZONE")
+}
+
+func (ics *icsWriter) observance(dtstart string, tr icsTransition) {
+	kind := "STANDARD"
+	if tr.isDaylight {
+		kind = "DAYLIGHT"
+	}
+	ics.line("BEGIN:" + kind)
+	ics.line("DTSTART:" + dtstart)
+	ics.line("TZOFFSETFROM:" + icsOffset(tr.from))
+	ics.line("TZOFFSETTO:" + icsOffset(tr.to))
+	ics.line("TZNAME:" + tr.abbrev)
+	ics.line("END:" + kind)
+}
+
+// zoneTransitions returns each offset change between start and end, found by
+// scanning daily and bisecting each change down to the second
+func zoneTransitions(start, end time.Time) []icsTransition {
+	loc := start.Location()
+	_, prev := start.Zone()
+
+	var out []icsTransition
+	for day := start; day.Before(end); day = day.Add(24 * time.Hour) {
+		next := day.Add(24 * time.Hour)
+		_, offset := next.Zone()
+		if offset == prev {
+			continue
+		}
+		lo, hi := day, next
+		for hi.Sub(lo) > time.Second {
+			mid := lo.Add(hi.Sub(lo) / 2)
+			if _, o := mid.Zone(); o == prev {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		abbrev, _ := hi.Zone()
+		isDaylight := offset > standardOffset(loc, hi.In(loc).Year())
+		out = append(out, icsTransition{at: hi, from: prev, to: offset, abbrev: abbrev, isDaylight: isDaylight})
+		prev = offset
+	}
+	return out
+}
+
+// standardOffset is the smaller of a zone's January and July offsets, which
+// covers standard time in both hemispheres
+func standardOffset(loc *time.Location, year int) int {
+	_, jan := time.Date(year, time.January, 1, 0, 0, 0, 0, loc).Zone()
+	_, jul := time.Date(year, time.July, 1, 0, 0, 0, 0, loc).Zone()
+	if jul < jan {
+		return jul
+	}
+	return jan
+}
+
+func icsOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+// icsEscape escapes TEXT values per RFC 5545
+func icsEscape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+// icsWriter writes CRLF-terminated content lines folded at 75 octets
+type icsWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ics *icsWriter) line(s string) {
+	if ics.err != nil {
+		return
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		n := len(string(r))
+		if width+n > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += n
+	}
+	b.WriteString("\r\n")
+	_, ics.err = io.WriteString(ics.w, b.String())
+}
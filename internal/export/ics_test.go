@@ -0,0 +1,122 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+func icsTrip(origin, destination string, departs time.Time) api.Trip {
+	return api.Trip{
+		ID: "t1", Cabin: "business", Source: "united",
+		AvailabilitySegments: []api.AvailabilitySegment{{
+			FlightNumber:       "UA1",
+			OriginAirport:      origin,
+			DestinationAirport: destination,
+			DepartsAt:          departs,
+			ArrivesAt:          departs.Add(11 * time.Hour),
+		}},
+	}
+}
+
+// vtimezone returns the VTIMEZONE block for a zone
+func vtimezone(t *testing.T, ics, name string) string {
+	t.Helper()
+	start := strings.Index(ics, "TZID:"+name+"\r\n")
+	if start < 0 {
+		t.Fatalf("no VTIMEZONE for %s:\n%s", name, ics)
+	}
+	return ics[start : start+strings.Index(ics[start:], "END:VTIMEZONE")]
+}
+
+func TestTripsToICSZones(t *testing.T) {
+	var buf bytes.Buffer
+	trip := icsTrip("SFO", "NRT", time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC))
+	if err := TripsToICS(&buf, []api.Trip{trip}, nil); err != nil {
+		t.Fatalf("TripsToICS: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.Contains(out, "DTSTART;TZID=America/Los_Angeles:20260301T100000") {
+		t.Errorf("departure not in local time:\n%s", out)
+	}
+
+	// Los Angeles: the previous November's fall back covers the March date,
+	// and every observance is a real change of offset
+	la := vtimezone(t, out, "America/Los_Angeles")
+	if !strings.Contains(la, "DTSTART:20251102T020000") {
+		t.Errorf("missing the transition before the exported date:\n%s", la)
+	}
+	if strings.Contains(la, "TZOFFSETFROM:-0800\r\nTZOFFSETTO:-0800") || strings.Contains(la, "TZOFFSETFROM:-0700\r\nTZOFFSETTO:-0700") {
+		t.Errorf("observance without an offset change:\n%s", la)
+	}
+
+	// Tokyo has a fixed offset: a single standard observance
+	tokyo := vtimezone(t, out, "Asia/Tokyo")
+	if strings.Count(tokyo, "BEGIN:STANDARD") != 1 || strings.Contains(tokyo, "DAYLIGHT") {
+		t.Errorf("want one STANDARD observance:\n%s", tokyo)
+	}
+	if !strings.Contains(tokyo, "TZOFFSETFROM:+0900\r\nTZOFFSETTO:+0900") {
+		t.Errorf("wrong fixed offset:\n%s", tokyo)
+	}
+}
+
+func TestTripsToICSUnzoned(t *testing.T) {
+	trips := []api.Trip{icsTrip("SFO", "ZZZ", time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC))}
+
+	var buf bytes.Buffer
+	if err := TripsToICS(&buf, trips, nil); err != nil {
+		t.Fatalf("TripsToICS: %v", err)
+	}
+	if !strings.Contains(buf.String(), "DTEND:20260302T050000Z") {
+		t.Errorf("unzoned arrival not in UTC:\n%s", buf.String())
+	}
+
+	if got := UnzonedAirports(trips, nil); len(got) != 1 || got[0] != "ZZZ" {
+		t.Errorf("UnzonedAirports = %v, want [ZZZ]", got)
+	}
+}
+
+func TestTripsToICSWithoutSegments(t *testing.T) {
+	departs := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	trips := []api.Trip{{
+		ID: "t1", AvailabilityID: "a1", RouteID: "r1", FlightNumbers: "UA837",
+		DepartsAt: departs, ArrivesAt: departs.Add(11 * time.Hour),
+	}}
+
+	tests := []struct {
+		name  string
+		avail []api.Availability
+		want  []string
+	}{
+		{
+			"route from the availability",
+			[]api.Availability{{ID: "a1", Route: api.Route{OriginAirport: "SFO", DestinationAirport: "NRT"}}},
+			[]string{"SUMMARY:UA837 SFO → NRT", "DTSTART;TZID=America/Los_Angeles:20260301T100000"},
+		},
+		{
+			// The route ID is opaque, so nothing is read from it
+			"no availability", nil,
+			[]string{"SUMMARY:UA837\r\n", "DTSTART:20260301T180000Z"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := TripsToICS(&buf, trips, tt.avail); err != nil {
+				t.Fatalf("TripsToICS: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("missing %q:\n%s", want, buf.String())
+				}
+			}
+			if tt.avail == nil && strings.Contains(buf.String(), "LOCATION:") {
+				t.Errorf("LOCATION without a known airport:\n%s", buf.String())
+			}
+		})
+	}
+}
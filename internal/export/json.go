@@ -33,3 +33,12 @@ func RoutesToJSON(w io.Writer, data []api.Route, pretty bool) error {
 	}
 	return encoder.Encode(data)
 }
+
+// WriteJSON exports any value as JSON
+func WriteJSON(w io.Writer, v interface{}, pretty bool) error {
+	encoder := json.NewEncoder(w)
+	if pretty {
+		encoder.SetIndent("", "  ")
+	}
+	return encoder.Encode(v)
+}
@@ -0,0 +1,43 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Envelope is an optional first NDJSON line describing the query that
+// produced the records that follow
+type Envelope struct {
+	Type      string      `json:"type"` // always "envelope"
+	Command   string      `json:"command"`
+	Query     interface{} `json:"query,omitempty"`
+	FetchedAt time.Time   `json:"fetchedAt"`
+}
+
+// NDJSONWriter writes newline-delimited JSON, one compact record per line.
+// Each record is written as soon as it is passed in, so output can be
+// streamed while pages are still being fetched.
+type NDJSONWriter struct {
+	encoder *json.Encoder
+}
+
+// NewNDJSONWriter creates an NDJSON writer
+func NewNDJSONWriter(w io.Writer) *NDJSONWriter {
+	return &NDJSONWriter{encoder: json.NewEncoder(w)}
+}
+
+// Envelope writes a metadata line for the query; call it before any records
+func (n *NDJSONWriter) Envelope(command string, query interface{}) error {
+	return n.encoder.Encode(Envelope{
+		Type:      "envelope",
+		Command:   command,
+		Query:     query,
+		FetchedAt: time.Now().UTC(),
+	})
+}
+
+// Write writes one record as a single line
+func (n *NDJSONWriter) Write(v interface{}) error {
+	return n.encoder.Encode(v)
+}
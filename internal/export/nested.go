@@ -0,0 +1,134 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/valuation"
+)
+
+// AvailabilityTrips is an availability with the trips fetched for it
+type AvailabilityTrips struct {
+	api.Availability
+	Trips      []api.Trip `json:"Trips"`
+	TripsError string     `json:"TripsError,omitempty"`
+}
+
+// AvailabilitySegments is an availability with its trips reduced to their
+// flight segments
+type AvailabilitySegments struct {
+	api.Availability
+	Trips      []TripSegments `json:"Trips"`
+	TripsError string         `json:"TripsError,omitempty"`
+}
+
+// NestedToJSON exports availabilities with their trips nested under each one,
+// or with segment granularity each trip's flight segments
+func NestedToJSON(w io.Writer, data []AvailabilityTrips, pretty bool, g Granularity) error {
+	encoder := json.NewEncoder(w)
+	if pretty {
+		encoder.SetIndent("", "  ")
+	}
+	if g != GranularitySegment {
+		return encoder.Encode(data)
+	}
+
+	out := make([]AvailabilitySegments, len(data))
+	for i, a := range data {
+		out[i] = AvailabilitySegments{
+			Availability: a.Availability,
+			Trips:        tripSegments(a.Trips),
+			TripsError:   a.TripsError,
+		}
+	}
+	return encoder.Encode(out)
+}
+
+// NestedToCSV flattens availabilities and their trips into one row per trip,
+// or with segment granularity one row per flight segment. Availabilities
+// without trips get a single row with the trip columns empty.
+func NestedToCSV(w io.Writer, data []AvailabilityTrips, vals valuation.Table, g Granularity) error {
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	header := []string{
+		"Availability_ID",
+		"Date",
+		"Origin",
+		"Destination",
+		"Source",
+		"Trip_ID",
+		"Cabin",
+		"Stops",
+		"Duration_Min",
+		"Miles",
+		"Taxes",
+		"Seats",
+		"Value",
+	}
+	segments := g == GranularitySegment
+	if segments {
+		header = append(header, segmentHeader...)
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, a := range data {
+		parent := []string{
+			a.ID,
+			a.Date,
+			a.Route.OriginAirport,
+			a.Route.DestinationAirport,
+			a.Source,
+		}
+
+		if len(a.Trips) == 0 {
+			row := append(append([]string{}, parent...), make([]string, len(header)-len(parent))...)
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, t := range a.Trips {
+			trip := append(append([]string{}, parent...),
+				t.ID,
+				t.Cabin,
+				strconv.Itoa(t.Stops),
+				strconv.Itoa(t.TotalDuration),
+				strconv.Itoa(t.PartyMiles()),
+				strconv.Itoa(t.PartyTaxes()),
+				strconv.Itoa(t.RemainingSeats),
+				formatValue(vals.Trip(t).Cost),
+			)
+
+			if !segments {
+				if err := writer.Write(trip); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if len(t.AvailabilitySegments) == 0 {
+				row := append(trip, make([]string, len(segmentHeader))...)
+				if err := writer.Write(row); err != nil {
+					return err
+				}
+				continue
+			}
+
+			for i, seg := range t.AvailabilitySegments {
+				row := append(append([]string{}, trip...), segmentColumns(i, seg)...)
+				if err := writer.Write(row); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
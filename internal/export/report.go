@@ -0,0 +1,305 @@
+package export
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/valuation"
+)
+
+// Summary describes the query behind a report, shown as its header
+type Summary struct {
+	Title  string
+	Fields []SummaryField
+}
+
+// SummaryField is one labelled query parameter
+type SummaryField struct {
+	Label string
+	Value string
+}
+
+// Add appends a field, skipping empty values
+func (s *Summary) Add(label, value string) {
+	if value != "" {
+		s.Fields = append(s.Fields, SummaryField{Label: label, Value: value})
+	}
+}
+
+// reportTable is the format-neutral table behind markdown and HTML reports
+type reportTable struct {
+	Columns []reportColumn
+	Rows    [][]reportCell
+}
+
+type reportColumn struct {
+	Name    string
+	Numeric bool
+}
+
+// reportCell is a rendered value with a sort key and an optional cabin code
+// used for color coding
+type reportCell struct {
+	Text  string
+	Sort  string
+	Cabin string
+}
+
+func textCell(s string) reportCell {
+	return reportCell{Text: s, Sort: s}
+}
+
+func numberCell(text string, n float64) reportCell {
+	return reportCell{Text: text, Sort: strconv.FormatFloat(n, 'f', -1, 64)}
+}
+
+// unavailableSort makes missing numeric values sort after every real one
+const unavailableSort = "1e18"
+
+func availabilityTable(data []api.Availability, vals valuation.Table) reportTable {
+	t := reportTable{Columns: []reportColumn{
+		{Name: "Date"}, {Name: "From"}, {Name: "To"}, {Name: "Program"},
+	}}
+	for _, cabin := range api.ValidCabins() {
+		t.Columns = append(t.Columns, reportColumn{Name: api.CabinDisplayName(cabin), Numeric: true})
+	}
+	t.Columns = append(t.Columns, reportColumn{Name: "Value", Numeric: true})
+
+	for _, a := range data {
+		row := []reportCell{
+			textCell(a.Date),
+			textCell(a.Route.OriginAirport),
+			textCell(a.Route.DestinationAirport),
+			textCell(api.SourceDisplayName(a.Source)),
+		}
+		for _, cabin := range api.ValidCabins() {
+			c := a.Cabin(cabin)
+			if !c.Available {
+				row = append(row, reportCell{Text: "–", Sort: unavailableSort})
+				continue
+			}
+			text := fmt.Sprintf("%s · %d seats", formatThousands(c.Miles), c.RemainingSeats)
+			if c.Direct {
+				text += " · direct"
+			}
+			row = append(row, reportCell{Text: text, Sort: strconv.Itoa(c.Miles), Cabin: cabin})
+		}
+		if v, cabin, ok := vals.Best(a); ok {
+			row = append(row, reportCell{Text: cabin + " " + v.String(), Sort: strconv.FormatFloat(v.Cost, 'f', 2, 64), Cabin: cabin})
+		} else {
+			row = append(row, reportCell{Text: "–", Sort: unavailableSort})
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
+
+func tripsTable(data []api.Trip, vals valuation.Table) reportTable {
+	t := reportTable{Columns: []reportColumn{
+		{Name: "Departs"}, {Name: "Arrives"}, {Name: "Flights"}, {Name: "Cabin"}, {Name: "Program"},
+		{Name: "Stops", Numeric: true}, {Name: "Duration", Numeric: true}, {Name: "Miles", Numeric: true},
+		{Name: "Taxes", Numeric: true}, {Name: "Seats", Numeric: true}, {Name: "Value", Numeric: true},
+	}}
+
+	for _, trip := range data {
+		cabin := api.CabinCode(trip.Cabin)
+		taxes := api.TaxesAmount(trip.PartyTaxes(), trip.TaxesCurrency)
+		v := vals.Trip(trip)
+		t.Rows = append(t.Rows, []reportCell{
+			textCell(trip.DepartsAt.Format("2006-01-02 15:04")),
+			textCell(trip.ArrivesAt.Format("2006-01-02 15:04")),
+			textCell(tripRoute(trip)),
+			{Text: api.CabinDisplayName(cabin), Sort: trip.Cabin, Cabin: cabin},
+			textCell(api.SourceDisplayName(trip.Source)),
+			numberCell(strconv.Itoa(trip.Stops), float64(trip.Stops)),
+			numberCell(formatMinutes(trip.TotalDuration), float64(trip.TotalDuration)),
+			numberCell(formatThousands(trip.PartyMiles()), float64(trip.PartyMiles())),
+			numberCell(trip.TaxesCurrencySymbol+api.FormatTaxes(trip.PartyTaxes(), trip.TaxesCurrency), taxes),
+			numberCell(strconv.Itoa(trip.RemainingSeats), float64(trip.RemainingSeats)),
+			numberCell(v.String(), v.Cost),
+		})
+	}
+	return t
+}
+
+// tripRoute summarizes a trip's flights, e.g. "UA837 SFO-NRT, NH5 NRT-SYD"
+func tripRoute(t api.Trip) string {
+	if len(t.AvailabilitySegments) == 0 {
+		return t.FlightNumbers
+	}
+	parts := make([]string, len(t.AvailabilitySegments))
+	for i, seg := range t.AvailabilitySegments {
+		parts[i] = fmt.Sprintf("%s %s-%s", seg.FlightNumber, seg.OriginAirport, seg.DestinationAirport)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatMinutes(minutes int) string {
+	return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+}
+
+func routesTable(data []api.Route) reportTable {
+	t := reportTable{Columns: []reportColumn{
+		{Name: "From"}, {Name: "To"}, {Name: "Distance", Numeric: true}, {Name: "Days Out", Numeric: true}, {Name: "Program"},
+	}}
+	for _, r := range data {
+		t.Rows = append(t.Rows, []reportCell{
+			textCell(r.OriginAirport),
+			textCell(r.DestinationAirport),
+			numberCell(formatThousands(r.Distance)+" mi", float64(r.Distance)),
+			numberCell(strconv.Itoa(r.NumDaysOut), float64(r.NumDaysOut)),
+			textCell(api.SourceDisplayName(r.Source)),
+		})
+	}
+	return t
+}
+
+// ToMarkdown exports availability as a markdown report
+func ToMarkdown(w io.Writer, data []api.Availability, summary Summary, vals valuation.Table) error {
+	return writeMarkdown(w, summary, availabilityTable(data, vals))
+}
+
+// TripsToMarkdown exports trips as a markdown report
+func TripsToMarkdown(w io.Writer, data []api.Trip, summary Summary, vals valuation.Table) error {
+	return writeMarkdown(w, summary, tripsTable(data, vals))
+}
+
+// RoutesToMarkdown exports routes as a markdown report
+func RoutesToMarkdown(w io.Writer, data []api.Route, summary Summary) error {
+	return writeMarkdown(w, summary, routesTable(data))
+}
+
+// ToHTML exports availability as a self-contained HTML report
+func ToHTML(w io.Writer, data []api.Availability, summary Summary, vals valuation.Table) error {
+	return writeHTML(w, summary, availabilityTable(data, vals))
+}
+
+// TripsToHTML exports trips as a self-contained HTML report
+func TripsToHTML(w io.Writer, data []api.Trip, summary Summary, vals valuation.Table) error {
+	return writeHTML(w, summary, tripsTable(data, vals))
+}
+
+// RoutesToHTML exports routes as a self-contained HTML report
+func RoutesToHTML(w io.Writer, data []api.Route, summary Summary) error {
+	return writeHTML(w, summary, routesTable(data))
+}
+
+func writeMarkdown(w io.Writer, summary Summary, t reportTable) error {
+	var b strings.Builder
+
+	if summary.Title != "" {
+		fmt.Fprintf(&b, "## %s\n\n", markdownEscape(summary.Title))
+	}
+	for _, f := range summary.Fields {
+		fmt.Fprintf(&b, "- **%s:** %s\n", markdownEscape(f.Label), markdownEscape(f.Value))
+	}
+	fmt.Fprintf(&b, "- **Results:** %d\n\n", len(t.Rows))
+
+	if len(t.Rows) == 0 {
+		b.WriteString("No results found.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	names := make([]string, len(t.Columns))
+	align := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		names[i] = markdownEscape(c.Name)
+		align[i] = "---"
+		if c.Numeric {
+			align[i] = "---:"
+		}
+	}
+	fmt.Fprintf(&b, "| %s |\n", strings.Join(names, " | "))
+	fmt.Fprintf(&b, "| %s |\n", strings.Join(align, " | "))
+
+	for _, row := range t.Rows {
+		cells := make([]string, len(row))
+		for i, c := range row {
+			cells[i] = markdownEscape(c.Text)
+		}
+		fmt.Fprintf(&b, "| %s |\n", strings.Join(cells, " | "))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownEscape keeps pipes and newlines from breaking table cells
+func markdownEscape(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Summary.Title}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; margin: 2rem; color: #111827; }
+h1 { color: #7C3AED; font-size: 1.4rem; }
+dl { display: grid; grid-template-columns: max-content auto; gap: .2rem 1rem; font-size: .9rem; }
+dt { color: #6B7280; }
+dd { margin: 0; }
+table { border-collapse: collapse; font-size: .85rem; }
+th { text-align: left; background: #F3F4F6; cursor: pointer; user-select: none; white-space: nowrap; }
+th.num, td.num { text-align: right; }
+th[aria-sort=ascending]::after { content: " ▲"; }
+th[aria-sort=descending]::after { content: " ▼"; }
+th, td { border: 1px solid #E5E7EB; padding: .35rem .6rem; }
+tr:nth-child(even) td { background: #F9FAFB; }
+td.Y { background: #DCFCE7 !important; }
+td.W { background: #DBEAFE !important; }
+td.J { background: #EDE9FE !important; }
+td.F { background: #FEF3C7 !important; }
+footer { margin-top: 1rem; color: #9CA3AF; font-size: .75rem; }
+</style>
+</head>
+<body>
+<h1>{{.Summary.Title}}</h1>
+<dl>
+{{range .Summary.Fields}}<dt>{{.Label}}</dt><dd>{{.Value}}</dd>
+{{end}}<dt>Results</dt><dd>{{len .Table.Rows}}</dd>
+</dl>
+{{if .Table.Rows}}<table>
+<thead><tr>{{range .Table.Columns}}<th{{if .Numeric}} class="num" data-numeric{{end}}>{{.Name}}</th>{{end}}</tr></thead>
+<tbody>
+{{range .Table.Rows}}<tr>{{range $i, $c := .}}{{$num := (index $.Table.Columns $i).Numeric}}<td data-sort="{{$c.Sort}}"{{if or $c.Cabin $num}} class="{{$c.Cabin}}{{if and $c.Cabin $num}} {{end}}{{if $num}}num{{end}}"{{end}}>{{$c.Text}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>{{else}}<p>No results found.</p>{{end}}
+<footer>Generated {{.Generated}} by seats</footer>
+<script>
+document.querySelectorAll("th").forEach(function (th, col) {
+  th.addEventListener("click", function () {
+    var tbody = th.closest("table").tBodies[0];
+    var numeric = th.hasAttribute("data-numeric");
+    var dir = th.getAttribute("aria-sort") === "ascending" ? -1 : 1;
+    th.parentNode.querySelectorAll("th").forEach(function (h) { h.removeAttribute("aria-sort"); });
+    th.setAttribute("aria-sort", dir === 1 ? "ascending" : "descending");
+    Array.from(tbody.rows).sort(function (a, b) {
+      var x = a.cells[col].dataset.sort, y = b.cells[col].dataset.sort;
+      var cmp = numeric ? parseFloat(x) - parseFloat(y) : x.localeCompare(y);
+      return cmp * dir;
+    }).forEach(function (row) { tbody.appendChild(row); });
+  });
+});
+</script>
+</body>
+</html>
+`))
+
+func writeHTML(w io.Writer, summary Summary, t reportTable) error {
+	if summary.Title == "" {
+		summary.Title = "Award availability"
+	}
+	return reportTemplate.Execute(w, struct {
+		Summary   Summary
+		Table     reportTable
+		Generated string
+	}{summary, t, time.Now().Format("2006-01-02 15:04")})
+}
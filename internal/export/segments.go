@@ -0,0 +1,175 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// Granularity selects whether flattened exports have one row per trip or
+// one row per flight segment
+type Granularity string
+
+const (
+	GranularityTrip    Granularity = "trip"
+	GranularitySegment Granularity = "segment"
+)
+
+// ParseGranularity validates a --granularity value
+func ParseGranularity(s string) (Granularity, error) {
+	switch g := Granularity(strings.ToLower(s)); g {
+	case GranularityTrip, GranularitySegment:
+		return g, nil
+	default:
+		return "", fmt.Errorf("invalid granularity %q (use trip or segment)", s)
+	}
+}
+
+// SegmentRecord is one flight segment of a trip
+type SegmentRecord struct {
+	Order              int       `json:"order"`
+	FlightNumber       string    `json:"flightNumber"`
+	OriginAirport      string    `json:"origin"`
+	DestinationAirport string    `json:"destination"`
+	DepartsAt          time.Time `json:"departsAt"`
+	ArrivesAt          time.Time `json:"arrivesAt"`
+	AircraftCode       string    `json:"aircraftCode,omitempty"`
+	AircraftName       string    `json:"aircraftName,omitempty"`
+	FareClass          string    `json:"fareClass,omitempty"`
+	Distance           int       `json:"distance"`
+	LayoverMinutes     int       `json:"layoverMinutes,omitempty"` // connection time before this segment
+}
+
+// TripSegments is a trip reduced to its identifying fields and segments
+type TripSegments struct {
+	TripID         string          `json:"tripId"`
+	AvailabilityID string          `json:"availabilityId"`
+	Cabin          string          `json:"cabin"`
+	Source         string          `json:"source"`
+	Segments       []SegmentRecord `json:"segments"`
+}
+
+// Segments returns the segment records of a trip in flight order
+func Segments(t api.Trip) []SegmentRecord {
+	records := make([]SegmentRecord, len(t.AvailabilitySegments))
+	for i, seg := range t.AvailabilitySegments {
+		records[i] = SegmentRecord{
+			Order:              i + 1,
+			FlightNumber:       seg.FlightNumber,
+			OriginAirport:      seg.OriginAirport,
+			DestinationAirport: seg.DestinationAirport,
+			DepartsAt:          seg.DepartsAt,
+			ArrivesAt:          seg.ArrivesAt,
+			AircraftCode:       seg.AircraftCode,
+			AircraftName:       seg.AircraftName,
+			FareClass:          seg.FareClass,
+			Distance:           seg.Distance,
+		}
+		if i > 0 {
+			records[i].LayoverMinutes = int(seg.DepartsAt.Sub(t.AvailabilitySegments[i-1].ArrivesAt).Minutes())
+		}
+	}
+	return records
+}
+
+// SegmentsToJSON exports trips as a nested list of their flight segments
+func SegmentsToJSON(w io.Writer, data []api.Trip, pretty bool) error {
+	encoder := json.NewEncoder(w)
+	if pretty {
+		encoder.SetIndent("", "  ")
+	}
+	return encoder.Encode(tripSegments(data))
+}
+
+// SegmentsToCSV exports one row per flight segment. Trips without segment
+// details get a single row with the segment columns empty.
+func SegmentsToCSV(w io.Writer, data []api.Trip) error {
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	header := append([]string{
+		"Trip_ID",
+		"Availability_ID",
+		"Cabin",
+		"Source",
+	}, segmentHeader...)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, t := range data {
+		trip := []string{
+			t.ID,
+			t.AvailabilityID,
+			t.Cabin,
+			t.Source,
+		}
+
+		if len(t.AvailabilitySegments) == 0 {
+			row := append(trip, make([]string, len(segmentHeader))...)
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for i, seg := range t.AvailabilitySegments {
+			row := append(append([]string{}, trip...), segmentColumns(i, seg)...)
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// tripSegments reduces trips to their identifying fields and segments
+func tripSegments(data []api.Trip) []TripSegments {
+	out := make([]TripSegments, len(data))
+	for i, t := range data {
+		out[i] = TripSegments{
+			TripID:         t.ID,
+			AvailabilityID: t.AvailabilityID,
+			Cabin:          t.Cabin,
+			Source:         t.Source,
+			Segments:       Segments(t),
+		}
+	}
+	return out
+}
+
+// segmentHeader names the per-segment columns shared by flattened exports
+var segmentHeader = []string{
+	"Segment",
+	"Flight_Number",
+	"Segment_Origin",
+	"Segment_Destination",
+	"Segment_Departs",
+	"Segment_Arrives",
+	"Aircraft_Code",
+	"Aircraft_Name",
+	"Fare_Class",
+	"Distance",
+}
+
+func segmentColumns(order int, seg api.AvailabilitySegment) []string {
+	return []string{
+		strconv.Itoa(order + 1),
+		seg.FlightNumber,
+		seg.OriginAirport,
+		seg.DestinationAirport,
+		seg.DepartsAt.Format("2006-01-02 15:04"),
+		seg.ArrivesAt.Format("2006-01-02 15:04"),
+		seg.AircraftCode,
+		seg.AircraftName,
+		seg.FareClass,
+		strconv.Itoa(seg.Distance),
+	}
+}
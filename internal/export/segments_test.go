@@ -0,0 +1,105 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/valuation"
+)
+
+func segmentTrips() []api.Trip {
+	departs := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	return []api.Trip{
+		{
+			ID: "t1", AvailabilityID: "a1", Cabin: "business", Source: "united",
+			AvailabilitySegments: []api.AvailabilitySegment{
+				{FlightNumber: "UA1", OriginAirport: "SFO", DestinationAirport: "LAX", DepartsAt: departs, ArrivesAt: departs.Add(time.Hour)},
+				{FlightNumber: "UA2", OriginAirport: "LAX", DestinationAirport: "NRT", DepartsAt: departs.Add(3 * time.Hour), ArrivesAt: departs.Add(14 * time.Hour)},
+			},
+		},
+		{ID: "t2", AvailabilityID: "a1", Cabin: "economy", Source: "united"},
+	}
+}
+
+func readCSV(t *testing.T, buf *bytes.Buffer) [][]string {
+	t.Helper()
+	rows, err := csv.NewReader(buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	return rows
+}
+
+func TestSegmentsToCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := SegmentsToCSV(&buf, segmentTrips()); err != nil {
+		t.Fatalf("SegmentsToCSV: %v", err)
+	}
+
+	rows := readCSV(t, &buf)
+	if len(rows) != 4 {
+		t.Fatalf("got %d rows, want header + 2 segments + 1 trip without segments", len(rows))
+	}
+	if rows[1][0] != "t1" || rows[1][4] != "1" || rows[2][4] != "2" {
+		t.Errorf("segment rows = %v", rows[1:3])
+	}
+	if rows[3][0] != "t2" || rows[3][4] != "" || len(rows[3]) != len(rows[0]) {
+		t.Errorf("trip without segments = %v", rows[3])
+	}
+}
+
+func TestNestedToCSVSegments(t *testing.T) {
+	data := []AvailabilityTrips{
+		{Availability: api.Availability{ID: "a1"}, Trips: segmentTrips()},
+		{Availability: api.Availability{ID: "a2"}},
+	}
+	var buf bytes.Buffer
+	if err := NestedToCSV(&buf, data, valuation.Table{}, GranularitySegment); err != nil {
+		t.Fatalf("NestedToCSV: %v", err)
+	}
+
+	rows := readCSV(t, &buf)
+	if len(rows) != 5 {
+		t.Fatalf("got %d rows, want header + 2 segments + 1 bare trip + 1 bare availability", len(rows))
+	}
+	if rows[4][0] != "a2" || rows[4][5] != "" {
+		t.Errorf("availability without trips = %v", rows[4])
+	}
+}
+
+func TestNestedToJSONGranularity(t *testing.T) {
+	data := []AvailabilityTrips{{Availability: api.Availability{ID: "a1"}, Trips: segmentTrips()}}
+
+	tests := []struct {
+		g    Granularity
+		want string // key present on each nested trip
+	}{
+		{GranularityTrip, "AvailabilitySegments"},
+		{GranularitySegment, "segments"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.g), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NestedToJSON(&buf, data, false, tt.g); err != nil {
+				t.Fatalf("NestedToJSON: %v", err)
+			}
+			var out []struct {
+				ID    string
+				Trips []map[string]json.RawMessage
+			}
+			if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if len(out) != 1 || out[0].ID != "a1" || len(out[0].Trips) != 2 {
+				t.Fatalf("unexpected output: %s", buf.String())
+			}
+			if _, ok := out[0].Trips[0][tt.want]; !ok {
+				t.Errorf("trip has no %q key: %s", tt.want, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package export
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	// Pure-Go SQLite driver, so exports build without cgo
+	_ "modernc.org/sqlite"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// Fetch describes the command run that produced an export, recorded in the
+// fetches table
+type Fetch struct {
+	Command    string
+	Query      interface{}
+	Passengers int // party size the results were narrowed for
+	FetchedAt  time.Time
+}
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS fetches (
+	id                 INTEGER PRIMARY KEY AUTOINCREMENT,
+	command            TEXT NOT NULL,
+	query              TEXT,
+	fetched_at         TEXT NOT NULL,
+	passengers         INTEGER NOT NULL DEFAULT 1,
+	availability_count INTEGER NOT NULL,
+	trip_count         INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS routes (
+	id           TEXT PRIMARY KEY,
+	origin       TEXT NOT NULL,
+	destination  TEXT NOT NULL,
+	distance     INTEGER,
+	num_days_out INTEGER,
+	source       TEXT
+);
+
+CREATE TABLE IF NOT EXISTS availability (
+	id             TEXT PRIMARY KEY,
+	route_id       TEXT REFERENCES routes(id),
+	date           TEXT NOT NULL,
+	source         TEXT NOT NULL,
+	taxes_currency TEXT,
+	created_at     TEXT,
+	updated_at     TEXT,
+	fetch_id       INTEGER REFERENCES fetches(id)
+);
+CREATE INDEX IF NOT EXISTS availability_date ON availability(date);
+
+CREATE TABLE IF NOT EXISTS offers (
+	availability_id TEXT NOT NULL REFERENCES availability(id) ON DELETE CASCADE,
+	cabin           TEXT NOT NULL,
+	miles           INTEGER,
+	seats           INTEGER,
+	direct          INTEGER NOT NULL,
+	airlines        TEXT,
+	taxes           INTEGER,
+	direct_miles    INTEGER,
+	direct_seats    INTEGER,
+	direct_airlines TEXT,
+	PRIMARY KEY (availability_id, cabin)
+);
+
+CREATE TABLE IF NOT EXISTS trips (
+	id              TEXT PRIMARY KEY,
+	availability_id TEXT,
+	route_id        TEXT,
+	cabin           TEXT,
+	source          TEXT,
+	stops           INTEGER,
+	duration        INTEGER,
+	carriers        TEXT,
+	flight_numbers  TEXT,
+	departs_at      TEXT,
+	arrives_at      TEXT,
+	miles           INTEGER,
+	taxes           INTEGER,
+	taxes_currency  TEXT,
+	seats           INTEGER,
+	fetch_id        INTEGER REFERENCES fetches(id)
+);
+CREATE INDEX IF NOT EXISTS trips_availability ON trips(availability_id);
+
+CREATE TABLE IF NOT EXISTS segments (
+	trip_id       TEXT NOT NULL REFERENCES trips(id) ON DELETE CASCADE,
+	seq           INTEGER NOT NULL,
+	flight_number TEXT,
+	origin        TEXT,
+	destination   TEXT,
+	departs_at    TEXT,
+	arrives_at    TEXT,
+	aircraft_code TEXT,
+	aircraft_name TEXT,
+	fare_class    TEXT,
+	distance      INTEGER,
+	PRIMARY KEY (trip_id, seq)
+);
+`
+
+// ToSQLite writes availability and trips into a SQLite database, creating
+// normalized tables on first use. Rows are upserted by ID so re-running an
+// export refreshes existing records; each run is recorded in fetches.
+// Miles and taxes are stored per passenger as the API reports them, with the
+// party size kept on the fetch.
+func ToSQLite(path string, data Workbook, fetch Fetch) error {
+	db, err := sql.Open("sqlite", sqliteDSN(path))
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		return fmt.Errorf("failed to create tables: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	fetchID, err := insertFetch(tx, data, fetch)
+	if err != nil {
+		return err
+	}
+	for _, a := range data.Availability {
+		if err := upsertAvailability(tx, a, fetchID); err != nil {
+			return fmt.Errorf("failed to store availability %s: %w", a.ID, err)
+		}
+	}
+	for _, t := range data.Trips {
+		if err := upsertTrip(tx, t, fetchID); err != nil {
+			return fmt.Errorf("failed to store trip %s: %w", t.ID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func insertFetch(tx *sql.Tx, data Workbook, fetch Fetch) (int64, error) {
+	query, err := json.Marshal(fetch.Query)
+	if err != nil {
+		return 0, err
+	}
+	if fetch.FetchedAt.IsZero() {
+		fetch.FetchedAt = time.Now()
+	}
+
+	res, err := tx.Exec(
+		`INSERT INTO fetches (command, query, fetched_at, passengers, availability_count, trip_count) VALUES (?, ?, ?, ?, ?, ?)`,
+		fetch.Command, string(query), sqliteTime(fetch.FetchedAt), max(fetch.Passengers, 1), len(data.Availability), len(data.Trips),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to record fetch: %w", err)
+	}
+	return res.LastInsertId()
+}
+
+func upsertAvailability(tx *sql.Tx, a api.Availability, fetchID int64) error {
+	routeID := a.RouteID
+	if routeID == "" {
+		routeID = a.Route.ID
+	}
+	if routeID != "" {
+		r := a.Route
+		r.ID = routeID
+		if err := upsertRoute(tx, r); err != nil {
+			return err
+		}
+	}
+
+	_, err := tx.Exec(`
+INSERT INTO availability (id, route_id, date, source, taxes_currency, created_at, updated_at, fetch_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET
+	route_id = excluded.route_id, date = excluded.date, source = excluded.source,
+	taxes_currency = excluded.taxes_currency, created_at = excluded.created_at,
+	updated_at = excluded.updated_at, fetch_id = excluded.fetch_id`,
+		a.ID, nullString(routeID), a.Date, a.Source, a.TaxesCurrency,
+		sqliteTime(a.CreatedAt), sqliteTime(a.UpdatedAt), fetchID,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Replace offers so cabins that are no longer available drop out
+	if _, err := tx.Exec(`DELETE FROM offers WHERE availability_id = ?`, a.ID); err != nil {
+		return err
+	}
+	raw := a
+	raw.Passengers = 0 // per-passenger API values rather than party totals
+	for _, cabin := range api.ValidCabins() {
+		c := raw.Cabin(cabin)
+		if !c.Available {
+			continue
+		}
+		_, err := tx.Exec(`
+INSERT INTO offers (availability_id, cabin, miles, seats, direct, airlines, taxes, direct_miles, direct_seats, direct_airlines)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			a.ID, cabin, c.Miles, c.RemainingSeats, c.Direct, c.Airlines, c.TotalTaxes,
+			c.DirectMiles, c.DirectRemainingSeats, c.DirectAirlines,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func upsertRoute(tx *sql.Tx, r api.Route) error {
+	_, err := tx.Exec(`
+INSERT INTO routes (id, origin, destination, distance, num_days_out, source)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET
+	origin = excluded.origin, destination = excluded.destination, distance = excluded.distance,
+	num_days_out = excluded.num_days_out, source = excluded.source`,
+		r.ID, r.OriginAirport, r.DestinationAirport, r.Distance, r.NumDaysOut, r.Source,
+	)
+	return err
+}
+
+func upsertTrip(tx *sql.Tx, t api.Trip, fetchID int64) error {
+	_, err := tx.Exec(`
+INSERT INTO trips (id, availability_id, route_id, cabin, source, stops, duration, carriers, flight_numbers,
+	departs_at, arrives_at, miles, taxes, taxes_currency, seats, fetch_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET
+	availability_id = excluded.availability_id, route_id = excluded.route_id, cabin = excluded.cabin,
+	source = excluded.source, stops = excluded.stops, duration = excluded.duration,
+	carriers = excluded.carriers, flight_numbers = excluded.flight_numbers,
+	departs_at = excluded.departs_at, arrives_at = excluded.arrives_at, miles = excluded.miles,
+	taxes = excluded.taxes, taxes_currency = excluded.taxes_currency, seats = excluded.seats,
+	fetch_id = excluded.fetch_id`,
+		t.ID, nullString(t.AvailabilityID), nullString(t.RouteID), t.Cabin, t.Source, t.Stops, t.TotalDuration,
+		t.Carriers, t.FlightNumbers, sqliteTime(t.DepartsAt), sqliteTime(t.ArrivesAt),
+		t.MileageCost, t.TotalTaxes, t.TaxesCurrency, t.RemainingSeats, fetchID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM segments WHERE trip_id = ?`, t.ID); err != nil {
+		return err
+	}
+	for i, seg := range t.AvailabilitySegments {
+		_, err := tx.Exec(`
+INSERT INTO segments (trip_id, seq, flight_number, origin, destination, departs_at, arrives_at,
+	aircraft_code, aircraft_name, fare_class, distance)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			t.ID, i+1, seg.FlightNumber, seg.OriginAirport, seg.DestinationAirport,
+			sqliteTime(seg.DepartsAt), sqliteTime(seg.ArrivesAt),
+			seg.AircraftCode, seg.AircraftName, seg.FareClass, seg.Distance,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sqliteDSN enables foreign keys through the DSN so the pragma holds on
+// every pooled connection, not just the first
+func sqliteDSN(path string) string {
+	return path + "?_pragma=foreign_keys(1)"
+}
+
+// sqliteTime stores times as UTC RFC 3339 text, which sorts and works with
+// SQLite's date functions; zero times are stored as NULL
+func sqliteTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
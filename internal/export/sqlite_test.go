@@ -0,0 +1,87 @@
+package export
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+func TestToSQLitePerPassenger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "awards.db")
+	data := Workbook{
+		Availability: []api.Availability{{
+			ID: "a1", RouteID: "r1", Route: api.Route{OriginAirport: "SFO", DestinationAirport: "NRT"},
+			Date: "2026-03-01", Source: "united", Passengers: 2,
+			JAvailable: true, JMileageCost: "70000", JTotalTaxes: 5600, JRemainingSeats: 2,
+		}},
+		Trips: []api.Trip{{ID: "t1", AvailabilityID: "a1", MileageCost: 70000, TotalTaxes: 5600, Passengers: 2}},
+	}
+	if err := ToSQLite(path, data, Fetch{Command: "search", Passengers: 2}); err != nil {
+		t.Fatalf("ToSQLite: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", sqliteDSN(path))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+
+	var offerMiles, offerTaxes, tripMiles, tripTaxes, passengers int
+	queries := []struct {
+		query string
+		dest  []any
+	}{
+		{`SELECT miles, taxes FROM offers WHERE availability_id = 'a1' AND cabin = 'J'`, []any{&offerMiles, &offerTaxes}},
+		{`SELECT miles, taxes FROM trips WHERE id = 't1'`, []any{&tripMiles, &tripTaxes}},
+		{`SELECT passengers FROM fetches`, []any{&passengers}},
+	}
+	for _, q := range queries {
+		if err := db.QueryRow(q.query).Scan(q.dest...); err != nil {
+			t.Fatalf("%s: %v", q.query, err)
+		}
+	}
+
+	if offerMiles != 70000 || offerTaxes != 5600 {
+		t.Errorf("offer = %d miles + %d taxes, want per-passenger 70000 + 5600", offerMiles, offerTaxes)
+	}
+	if tripMiles != 70000 || tripTaxes != 5600 {
+		t.Errorf("trip = %d miles + %d taxes, want per-passenger 70000 + 5600", tripMiles, tripTaxes)
+	}
+	if passengers != 2 {
+		t.Errorf("fetch passengers = %d, want 2", passengers)
+	}
+}
+
+func TestSQLiteForeignKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "awards.db")
+	if err := ToSQLite(path, Workbook{}, Fetch{Command: "search"}); err != nil {
+		t.Fatalf("ToSQLite: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", sqliteDSN(path))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(2)
+
+	// Hold one connection so the next query needs a second one
+	conn, err := db.Conn(t.Context())
+	if err != nil {
+		t.Fatalf("conn: %v", err)
+	}
+	defer conn.Close()
+
+	var on int
+	if err := db.QueryRow("PRAGMA foreign_keys").Scan(&on); err != nil {
+		t.Fatalf("pragma: %v", err)
+	}
+	if on != 1 {
+		t.Errorf("foreign_keys = %d on a pooled connection, want 1", on)
+	}
+	if _, err := db.Exec(`INSERT INTO offers (availability_id, cabin, direct) VALUES ('missing', 'J', 0)`); err == nil {
+		t.Error("insert of an orphan offer succeeded, want a foreign key error")
+	}
+}
@@ -0,0 +1,79 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// TemplateFuncs are the helpers available to --template output
+var TemplateFuncs = template.FuncMap{
+	// cabin turns a cabin code or name into its display name, e.g. "J" -> "Business"
+	"cabin": func(cabin string) string {
+		return api.CabinDisplayName(api.CabinCode(cabin))
+	},
+	// source turns a source code into its program name
+	"source": api.SourceDisplayName,
+	// miles formats a mileage cost with separators, e.g. "70,000"
+	"miles": func(v interface{}) string {
+		n, ok := templateInt(v)
+		if !ok {
+			return fmt.Sprint(v)
+		}
+		return formatThousands(n)
+	},
+	// milesk formats a mileage cost in thousands, e.g. "70k"
+	"milesk": func(v interface{}) string {
+		n, ok := templateInt(v)
+		if !ok {
+			return fmt.Sprint(v)
+		}
+		if n%1000 == 0 {
+			return fmt.Sprintf("%dk", n/1000)
+		}
+		return fmt.Sprintf("%.1fk", float64(n)/1000)
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"join":  strings.Join,
+}
+
+// templateInt accepts the int and string mileage fields used by the API
+func templateInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case string:
+		i, err := strconv.Atoi(n)
+		return i, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// ParseTemplate parses a per-record output template. A trailing newline is
+// added when missing so each record lands on its own line.
+func ParseTemplate(text string) (*template.Template, error) {
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	tmpl, err := template.New("output").Funcs(TemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// WriteTemplate executes the template once per record
+func WriteTemplate[T any](w io.Writer, tmpl *template.Template, records []T) error {
+	for _, r := range records {
+		if err := tmpl.Execute(w, r); err != nil {
+			return fmt.Errorf("template failed: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,343 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/JHill6253/seats-aero-cli/internal/airports"
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/valuation"
+)
+
+// Workbook is the data written to an XLSX export; sheets are only added for
+// the parts that have data
+type Workbook struct {
+	Availability []api.Availability
+	Trips        []api.Trip
+}
+
+// cabinFills are the background colors used to code cabins, matching the
+// HTML report
+var cabinFills = map[string]string{
+	"Y": "DCFCE7",
+	"W": "DBEAFE",
+	"J": "EDE9FE",
+	"F": "FEF3C7",
+}
+
+const (
+	xlsxDateFormat     = "yyyy-mm-dd"
+	xlsxDateTimeFormat = "yyyy-mm-dd hh:mm"
+	xlsxMilesFormat    = "#,##0"
+	xlsxMoneyFormat    = "#,##0.00"
+)
+
+// ToXLSX writes an Excel workbook with availability, trips and segments on
+// separate sheets. Miles and prices are numeric cells, dates are real dates
+// (segment times are local to their airports), headers are frozen with
+// autofilters, and cabins are color coded.
+func ToXLSX(w io.Writer, wb Workbook, vals valuation.Table) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	x := &xlsxWriter{f: f}
+	if err := x.styles(); err != nil {
+		return err
+	}
+
+	if len(wb.Availability) > 0 || len(wb.Trips) == 0 {
+		if err := x.availability(wb.Availability, vals); err != nil {
+			return err
+		}
+	}
+	if len(wb.Trips) > 0 {
+		if err := x.trips(wb.Trips, availabilityRoutes(wb.Availability), vals); err != nil {
+			return err
+		}
+		if err := x.segments(wb.Trips); err != nil {
+			return err
+		}
+	}
+
+	// NewFile starts with a default sheet that every export replaces
+	if err := f.DeleteSheet("Sheet1"); err != nil {
+		return err
+	}
+	f.SetActiveSheet(0)
+
+	if err := f.Write(w); err != nil {
+		return fmt.Errorf("failed to write workbook: %w", err)
+	}
+	return nil
+}
+
+type xlsxWriter struct {
+	f *excelize.File
+
+	header, date, dateTime, miles, money int
+	cabinFill                            map[string]int
+}
+
+func (x *xlsxWriter) styles() error {
+	var err error
+	if x.header, err = x.f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"F3F4F6"}},
+	}); err != nil {
+		return err
+	}
+	for _, s := range []struct {
+		id     *int
+		format string
+	}{
+		{&x.date, xlsxDateFormat},
+		{&x.dateTime, xlsxDateTimeFormat},
+		{&x.miles, xlsxMilesFormat},
+		{&x.money, xlsxMoneyFormat},
+	} {
+		format := s.format
+		if *s.id, err = x.f.NewStyle(&excelize.Style{CustomNumFmt: &format}); err != nil {
+			return err
+		}
+	}
+
+	x.cabinFill = map[string]int{}
+	for cabin, color := range cabinFills {
+		id, err := x.f.NewConditionalStyle(&excelize.Style{
+			Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{color}},
+		})
+		if err != nil {
+			return err
+		}
+		x.cabinFill[cabin] = id
+	}
+	return nil
+}
+
+// sheet creates a sheet, writes its header, freezes it and adds an autofilter
+// over the rows that follow
+func (x *xlsxWriter) sheet(name string, header []string, rows int) error {
+	if _, err := x.f.NewSheet(name); err != nil {
+		return err
+	}
+	if err := x.f.SetSheetRow(name, "A1", &header); err != nil {
+		return err
+	}
+
+	last, err := excelize.ColumnNumberToName(len(header))
+	if err != nil {
+		return err
+	}
+	if err := x.f.SetCellStyle(name, "A1", last+"1", x.header); err != nil {
+		return err
+	}
+	if err := x.f.SetColWidth(name, "A", last, 14); err != nil {
+		return err
+	}
+	if err := x.f.SetPanes(name, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	}); err != nil {
+		return err
+	}
+	return x.f.AutoFilter(name, fmt.Sprintf("A1:%s%d", last, rows+1), nil)
+}
+
+// column applies a number format to a whole data column
+func (x *xlsxWriter) column(sheet string, col, rows, style int) error {
+	if rows == 0 {
+		return nil
+	}
+	name, err := excelize.ColumnNumberToName(col)
+	if err != nil {
+		return err
+	}
+	return x.f.SetCellStyle(sheet, name+"2", fmt.Sprintf("%s%d", name, rows+1), style)
+}
+
+// fillWhen color codes a column by cabin with a conditional format; match
+// builds the rule for each cabin
+func (x *xlsxWriter) fillWhen(sheet string, col, rows int, match func(cabin string) (criteria, value string)) error {
+	if rows == 0 {
+		return nil
+	}
+	name, err := excelize.ColumnNumberToName(col)
+	if err != nil {
+		return err
+	}
+	ref := fmt.Sprintf("%s2:%s%d", name, name, rows+1)
+
+	var opts []excelize.ConditionalFormatOptions
+	for _, cabin := range api.ValidCabins() {
+		criteria, value := match(cabin)
+		if criteria == "" {
+			continue
+		}
+		format := x.cabinFill[cabin]
+		opts = append(opts, excelize.ConditionalFormatOptions{
+			Type:     "cell",
+			Criteria: criteria,
+			Value:    value,
+			Format:   &format,
+		})
+	}
+	return x.f.SetConditionalFormat(sheet, ref, opts)
+}
+
+func (x *xlsxWriter) availability(data []api.Availability, vals valuation.Table) error {
+	const name = "Availability"
+	header := []string{"ID", "Date", "Origin", "Destination", "Program"}
+	for _, cabin := range api.ValidCabins() {
+		header = append(header, cabin+" Miles", cabin+" Seats", cabin+" Direct")
+	}
+	header = append(header, "Best Value", "Best Cabin")
+
+	if err := x.sheet(name, header, len(data)); err != nil {
+		return err
+	}
+
+	for i, a := range data {
+		row := []interface{}{a.ID, xlsxDate(a.Date), a.Route.OriginAirport, a.Route.DestinationAirport, api.SourceDisplayName(a.Source)}
+		for _, cabin := range api.ValidCabins() {
+			c := a.Cabin(cabin)
+			if !c.Available {
+				row = append(row, nil, nil, nil)
+				continue
+			}
+			row = append(row, c.Miles, c.RemainingSeats, c.Direct)
+		}
+		if v, cabin, ok := vals.Best(a); ok {
+			row = append(row, v.Cost, cabin)
+		} else {
+			row = append(row, nil, nil)
+		}
+		if err := x.f.SetSheetRow(name, fmt.Sprintf("A%d", i+2), &row); err != nil {
+			return err
+		}
+	}
+
+	if err := x.column(name, 2, len(data), x.date); err != nil {
+		return err
+	}
+	for i, cabin := range api.ValidCabins() {
+		col := 6 + i*3
+		if err := x.column(name, col, len(data), x.miles); err != nil {
+			return err
+		}
+		if err := x.fillWhen(name, col, len(data), func(c string) (string, string) {
+			if c != cabin {
+				return "", ""
+			}
+			return ">", "0"
+		}); err != nil {
+			return err
+		}
+	}
+	valueCol := 6 + len(api.ValidCabins())*3
+	if err := x.column(name, valueCol, len(data), x.money); err != nil {
+		return err
+	}
+	return x.fillWhen(name, valueCol+1, len(data), func(cabin string) (string, string) {
+		return "==", `"` + cabin + `"`
+	})
+}
+
+func (x *xlsxWriter) trips(data []api.Trip, routes map[string]api.Route, vals valuation.Table) error {
+	const name = "Trips"
+	header := []string{
+		"Trip ID", "Availability ID", "Departs", "Arrives", "Origin", "Destination", "Flights",
+		"Cabin", "Program", "Stops", "Duration (min)", "Miles", "Taxes", "Currency", "Seats", "Value",
+	}
+	if err := x.sheet(name, header, len(data)); err != nil {
+		return err
+	}
+
+	for i, t := range data {
+		origin, destination := tripEndpoints(t, routes)
+		departs, arrives := wallClock(t.DepartsAt), wallClock(t.ArrivesAt)
+		if segs := t.AvailabilitySegments; len(segs) > 0 {
+			departs = wallClock(airports.Local(origin, segs[0].DepartsAt))
+			arrives = wallClock(airports.Local(destination, segs[len(segs)-1].ArrivesAt))
+		}
+		row := []interface{}{
+			t.ID, t.AvailabilityID, departs, arrives, origin, destination, tripRoute(t),
+			strings.ToLower(t.Cabin), api.SourceDisplayName(t.Source), t.Stops, t.TotalDuration,
+			t.PartyMiles(), api.TaxesAmount(t.PartyTaxes(), t.TaxesCurrency), t.TaxesCurrency, t.RemainingSeats, vals.Trip(t).Cost,
+		}
+		if err := x.f.SetSheetRow(name, fmt.Sprintf("A%d", i+2), &row); err != nil {
+			return err
+		}
+	}
+
+	for col, style := range map[int]int{3: x.dateTime, 4: x.dateTime, 12: x.miles, 13: x.money, 16: x.money} {
+		if err := x.column(name, col, len(data), style); err != nil {
+			return err
+		}
+	}
+	return x.fillWhen(name, 8, len(data), func(cabin string) (string, string) {
+		return "==", `"` + strings.ToLower(api.CabinParam(cabin)) + `"`
+	})
+}
+
+func (x *xlsxWriter) segments(data []api.Trip) error {
+	const name = "Segments"
+	header := []string{
+		"Trip ID", "Cabin", "Segment", "Flight", "Origin", "Destination", "Departs", "Arrives",
+		"Aircraft Code", "Aircraft", "Fare Class", "Distance", "Layover (min)",
+	}
+
+	rows := 0
+	for _, t := range data {
+		rows += len(t.AvailabilitySegments)
+	}
+	if err := x.sheet(name, header, rows); err != nil {
+		return err
+	}
+
+	r := 2
+	for _, t := range data {
+		for _, seg := range Segments(t) {
+			row := []interface{}{
+				t.ID, strings.ToLower(t.Cabin), seg.Order, seg.FlightNumber, seg.OriginAirport, seg.DestinationAirport,
+				wallClock(airports.Local(seg.OriginAirport, seg.DepartsAt)),
+				wallClock(airports.Local(seg.DestinationAirport, seg.ArrivesAt)),
+				seg.AircraftCode, seg.AircraftName, seg.FareClass, seg.Distance, seg.LayoverMinutes,
+			}
+			if err := x.f.SetSheetRow(name, fmt.Sprintf("A%d", r), &row); err != nil {
+				return err
+			}
+			r++
+		}
+	}
+
+	for col, style := range map[int]int{7: x.dateTime, 8: x.dateTime, 12: x.miles} {
+		if err := x.column(name, col, rows, style); err != nil {
+			return err
+		}
+	}
+	return x.fillWhen(name, 2, rows, func(cabin string) (string, string) {
+		return "==", `"` + strings.ToLower(api.CabinParam(cabin)) + `"`
+	})
+}
+
+// xlsxDate parses an availability date so it is stored as a real date,
+// keeping the original text if it doesn't parse
+func xlsxDate(s string) interface{} {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return s
+	}
+	return t
+}
+
+// wallClock keeps the local clock reading of t but drops its zone, since
+// spreadsheet dates have no time zone and excelize stores times as UTC
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
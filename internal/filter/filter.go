@@ -0,0 +1,60 @@
+package filter
+
+import (
+	"strings"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// Options narrows availability results after they are fetched
+type Options struct {
+	Cabins     []string `yaml:"cabins,omitempty"` // Y, W, J, F; empty for all
+	MaxMiles   int      `yaml:"max_miles,omitempty"`
+	MinSeats   int      `yaml:"min_seats,omitempty"`
+	DirectOnly bool     `yaml:"direct_only,omitempty"`
+}
+
+// IsZero reports whether no filters are set
+func (o Options) IsZero() bool {
+	return len(o.Cabins) == 0 && o.MaxMiles == 0 && o.MinSeats == 0 && !o.DirectOnly
+}
+
+// Matches reports whether a single cabin satisfies the filters
+func (o Options) Matches(c api.CabinAvailability) bool {
+	if !c.Available {
+		return false
+	}
+	if o.MaxMiles > 0 && (c.Miles == 0 || c.Miles > o.MaxMiles) {
+		return false
+	}
+	if o.MinSeats > 0 && c.RemainingSeats < o.MinSeats {
+		return false
+	}
+	if o.DirectOnly && !c.Direct {
+		return false
+	}
+	return true
+}
+
+// Availability returns the results where at least one selected cabin matches
+func Availability(data []api.Availability, opts Options) []api.Availability {
+	if opts.IsZero() {
+		return data
+	}
+
+	cabins := opts.Cabins
+	if len(cabins) == 0 {
+		cabins = api.ValidCabins()
+	}
+
+	result := make([]api.Availability, 0, len(data))
+	for _, a := range data {
+		for _, cabin := range cabins {
+			if opts.Matches(a.Cabin(strings.ToUpper(cabin))) {
+				result = append(result, a)
+				break
+			}
+		}
+	}
+	return result
+}
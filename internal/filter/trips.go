@@ -0,0 +1,232 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/airports"
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// TripOptions narrows trips by their connections, times, carriers and aircraft.
+// Times of day are compared in the local time of the relevant airport.
+type TripOptions struct {
+	MaxStops    int // -1 for no limit
+	MinLayover  time.Duration
+	MaxLayover  time.Duration
+	NoOvernight bool // exclude layovers that span local midnight
+	NoRedEye    bool // exclude segments that fly overnight
+	MaxDuration time.Duration
+
+	DepartWindow Window // first departure, at the origin
+	ArriveWindow Window // final arrival, at the destination
+
+	Carriers        []string // every segment must be operated by one of these
+	ExcludeCarriers []string // no segment may be operated by these
+	Aircraft        []string // at least one segment must use one of these
+	ExcludeAircraft []string // no segment may use these
+}
+
+// Window is a time-of-day range; an end before the start wraps past midnight
+type Window struct {
+	Start, End time.Duration // offsets from midnight
+	set        bool
+}
+
+// ParseWindow parses a window like "08:00-14:30"; empty means no window
+func ParseWindow(s string) (Window, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Window{}, nil
+	}
+
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return Window{}, fmt.Errorf("invalid time window %q (use HH:MM-HH:MM)", s)
+	}
+	start, err := parseClock(parts[0])
+	if err != nil {
+		return Window{}, fmt.Errorf("invalid time window %q: %w", s, err)
+	}
+	end, err := parseClock(parts[1])
+	if err != nil {
+		return Window{}, fmt.Errorf("invalid time window %q: %w", s, err)
+	}
+	return Window{Start: start, End: end, set: true}, nil
+}
+
+// Contains reports whether the time of day of t falls in the window
+func (w Window) Contains(t time.Time) bool {
+	if !w.set {
+		return true
+	}
+	tod := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if w.Start <= w.End {
+		return tod >= w.Start && tod <= w.End
+	}
+	return tod >= w.Start || tod <= w.End
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// IsZero reports whether no trip filters are set
+func (o TripOptions) IsZero() bool {
+	return o.MaxStops < 0 && o.MinLayover == 0 && o.MaxLayover == 0 && !o.NoOvernight &&
+		!o.NoRedEye && o.MaxDuration == 0 && !o.DepartWindow.set && !o.ArriveWindow.set &&
+		len(o.Carriers) == 0 && len(o.ExcludeCarriers) == 0 && len(o.Aircraft) == 0 && len(o.ExcludeAircraft) == 0
+}
+
+// Trips returns the trips that satisfy every option
+func Trips(trips []api.Trip, opts TripOptions) []api.Trip {
+	if opts.IsZero() {
+		return trips
+	}
+
+	result := make([]api.Trip, 0, len(trips))
+	for _, t := range trips {
+		if opts.MatchesTrip(t) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// MatchesTrip reports whether a single trip satisfies the options
+func (o TripOptions) MatchesTrip(t api.Trip) bool {
+	if o.MaxStops >= 0 && t.Stops > o.MaxStops {
+		return false
+	}
+	if o.MaxDuration > 0 && time.Duration(t.TotalDuration)*time.Minute > o.MaxDuration {
+		return false
+	}
+
+	segs := t.AvailabilitySegments
+	if len(segs) == 0 {
+		return o.matchesUnknownSegments(t)
+	}
+
+	// Local time checks are skipped at airports without a known time zone
+	// rather than judged on UTC
+	first, last := segs[0], segs[len(segs)-1]
+	if airports.HasTimeZone(first.OriginAirport) && !o.DepartWindow.Contains(airports.Local(first.OriginAirport, first.DepartsAt)) {
+		return false
+	}
+	if airports.HasTimeZone(last.DestinationAirport) && !o.ArriveWindow.Contains(airports.Local(last.DestinationAirport, last.ArrivesAt)) {
+		return false
+	}
+
+	aircraftMatched := len(o.Aircraft) == 0
+	for i, seg := range segs {
+		carrier := SegmentCarrier(seg)
+		if len(o.Carriers) > 0 && !containsFold(o.Carriers, carrier) {
+			return false
+		}
+		if containsFold(o.ExcludeCarriers, carrier) {
+			return false
+		}
+		if containsFold(o.ExcludeAircraft, seg.AircraftCode) {
+			return false
+		}
+		if containsFold(o.Aircraft, seg.AircraftCode) {
+			aircraftMatched = true
+		}
+		if o.NoRedEye && IsRedEye(seg) {
+			return false
+		}
+
+		if i == 0 {
+			continue
+		}
+		prev := segs[i-1]
+		layover := seg.DepartsAt.Sub(prev.ArrivesAt)
+		if o.MinLayover > 0 && layover < o.MinLayover {
+			return false
+		}
+		if o.MaxLayover > 0 && layover > o.MaxLayover {
+			return false
+		}
+		if o.NoOvernight && IsOvernight(prev, seg) {
+			return false
+		}
+	}
+
+	return aircraftMatched
+}
+
+// matchesUnknownSegments judges a trip without segment details. Carrier and
+// aircraft requirements can't be confirmed so they fail; the trip's carrier
+// list still rules out excluded carriers. Local-time windows and the red-eye
+// check are skipped, as at airports without a known time zone, since the
+// trip's times can't be placed at an airport.
+func (o TripOptions) matchesUnknownSegments(t api.Trip) bool {
+	if len(o.Carriers) > 0 || len(o.Aircraft) > 0 {
+		return false
+	}
+	for _, carrier := range strings.Split(t.Carriers, ",") {
+		if containsFold(o.ExcludeCarriers, strings.TrimSpace(carrier)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Layovers returns the connection times between consecutive segments
+func Layovers(t api.Trip) []time.Duration {
+	segs := t.AvailabilitySegments
+	if len(segs) < 2 {
+		return nil
+	}
+	out := make([]time.Duration, 0, len(segs)-1)
+	for i := 1; i < len(segs); i++ {
+		out = append(out, segs[i].DepartsAt.Sub(segs[i-1].ArrivesAt))
+	}
+	return out
+}
+
+// SegmentCarrier returns the marketing carrier code from a flight number
+func SegmentCarrier(seg api.AvailabilitySegment) string {
+	if len(seg.FlightNumber) < 2 {
+		return seg.FlightNumber
+	}
+	return strings.ToUpper(seg.FlightNumber[:2])
+}
+
+// IsOvernight reports whether the connection between two segments spans
+// local midnight at the connecting airport. It is false when the airport's
+// time zone is unknown.
+func IsOvernight(arrive, depart api.AvailabilitySegment) bool {
+	if !airports.HasTimeZone(arrive.DestinationAirport) {
+		return false
+	}
+	in := airports.Local(arrive.DestinationAirport, arrive.ArrivesAt)
+	out := airports.Local(arrive.DestinationAirport, depart.DepartsAt)
+	return in.YearDay() != out.YearDay() || in.Year() != out.Year()
+}
+
+// IsRedEye reports whether a segment leaves late at night (21:00-03:59 local)
+// and lands in the early morning (04:00-09:59 local). It is false when either
+// airport's time zone is unknown.
+func IsRedEye(seg api.AvailabilitySegment) bool {
+	if !airports.HasTimeZone(seg.OriginAirport) || !airports.HasTimeZone(seg.DestinationAirport) {
+		return false
+	}
+	dep := airports.Local(seg.OriginAirport, seg.DepartsAt).Hour()
+	arr := airports.Local(seg.DestinationAirport, seg.ArrivesAt).Hour()
+	return (dep >= 21 || dep < 4) && arr >= 4 && arr < 10
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+func utc(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestIsRedEye(t *testing.T) {
+	tests := []struct {
+		name string
+		seg  api.AvailabilitySegment
+		want bool
+	}{
+		// 22:30 PDT to 06:45 EDT
+		{"red-eye", api.AvailabilitySegment{OriginAirport: "SFO", DestinationAirport: "JFK", DepartsAt: utc("2024-06-02T05:30:00Z"), ArrivesAt: utc("2024-06-02T10:45:00Z")}, true},
+		// 08:00 PDT to 16:15 EDT
+		{"daytime", api.AvailabilitySegment{OriginAirport: "SFO", DestinationAirport: "JFK", DepartsAt: utc("2024-06-01T15:00:00Z"), ArrivesAt: utc("2024-06-01T20:15:00Z")}, false},
+		// Would be a red-eye in UTC, but the origin's zone is unknown
+		{"unknown airport", api.AvailabilitySegment{OriginAirport: "ZZZ", DestinationAirport: "JFK", DepartsAt: utc("2024-06-01T22:00:00Z"), ArrivesAt: utc("2024-06-02T10:45:00Z")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRedEye(tt.seg); got != tt.want {
+				t.Errorf("IsRedEye = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsOvernight(t *testing.T) {
+	arrive := api.AvailabilitySegment{DestinationAirport: "NRT", ArrivesAt: utc("2024-06-01T13:00:00Z")} // 22:00 JST
+	depart := api.AvailabilitySegment{OriginAirport: "NRT", DepartsAt: utc("2024-06-01T16:00:00Z")}      // 01:00 JST
+	if !IsOvernight(arrive, depart) {
+		t.Error("connection across local midnight not overnight")
+	}
+
+	// Same UTC day, but the zone is unknown so it can't be judged
+	arrive.DestinationAirport, depart.OriginAirport = "ZZZ", "ZZZ"
+	arrive.ArrivesAt, depart.DepartsAt = utc("2024-06-01T23:00:00Z"), utc("2024-06-02T01:00:00Z")
+	if IsOvernight(arrive, depart) {
+		t.Error("connection at an unknown airport judged overnight")
+	}
+}
+
+func TestMatchesTripWindows(t *testing.T) {
+	window, err := ParseWindow("08:00-14:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := TripOptions{MaxStops: -1, DepartWindow: window}
+
+	trip := func(origin, departs string) api.Trip {
+		return api.Trip{AvailabilitySegments: []api.AvailabilitySegment{{
+			OriginAirport: origin, DestinationAirport: "NRT", DepartsAt: utc(departs), ArrivesAt: utc(departs).Add(11 * time.Hour),
+		}}}
+	}
+
+	if !opts.MatchesTrip(trip("SFO", "2024-06-01T17:00:00Z")) { // 10:00 PDT
+		t.Error("10:00 local departure rejected")
+	}
+	if opts.MatchesTrip(trip("SFO", "2024-06-01T10:00:00Z")) { // 03:00 PDT
+		t.Error("03:00 local departure accepted")
+	}
+	if !opts.MatchesTrip(trip("ZZZ", "2024-06-01T03:00:00Z")) {
+		t.Error("departure from an airport without a time zone rejected")
+	}
+}
+
+func TestMatchesTripWithoutSegments(t *testing.T) {
+	window, err := ParseWindow("08:00-14:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Departs 03:00 UTC: outside the window in UTC, but no airport to place it
+	trip := api.Trip{Carriers: "UA, NH", DepartsAt: utc("2024-06-01T03:00:00Z"), ArrivesAt: utc("2024-06-01T14:00:00Z")}
+
+	tests := []struct {
+		name string
+		opts TripOptions
+		want bool
+	}{
+		{"no filters", TripOptions{MaxStops: -1}, true},
+		{"depart window skipped", TripOptions{MaxStops: -1, DepartWindow: window}, true},
+		{"arrive window skipped", TripOptions{MaxStops: -1, ArriveWindow: window}, true},
+		{"red-eye skipped", TripOptions{MaxStops: -1, NoRedEye: true}, true},
+		{"carriers unknown", TripOptions{MaxStops: -1, Carriers: []string{"UA", "NH"}}, false},
+		{"aircraft unknown", TripOptions{MaxStops: -1, Aircraft: []string{"388"}}, false},
+		{"excluded aircraft unknown", TripOptions{MaxStops: -1, ExcludeAircraft: []string{"388"}}, true},
+		{"excluded carrier listed", TripOptions{MaxStops: -1, ExcludeCarriers: []string{"nh"}}, false},
+		{"excluded carrier absent", TripOptions{MaxStops: -1, ExcludeCarriers: []string{"AA"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.MatchesTrip(trip); got != tt.want {
+				t.Errorf("MatchesTrip = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// Snapshot is the result of a single run of a named search
+type Snapshot struct {
+	Name      string             `json:"name"`
+	FetchedAt time.Time          `json:"fetchedAt"`
+	Results   []api.Availability `json:"results"`
+}
+
+// DefaultKeep is how many snapshots of each search are kept by default
+const DefaultKeep = 100
+
+// Store keeps a log of snapshots per search, one JSON Lines file each, plus
+// the latest snapshot in its own small file so reading it doesn't rescan the
+// log. Once a log grows past twice the retention cap it is trimmed to the
+// most recent snapshots.
+type Store struct {
+	dir  string
+	keep int
+
+	mu     sync.Mutex
+	counts map[string]int // snapshots in each log, counted on first append
+}
+
+// DefaultDir returns the history directory next to config.yaml
+func DefaultDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "seats-aero", "history"), nil
+}
+
+// NewStore creates a store rooted at dir that keeps DefaultKeep snapshots
+// per search
+func NewStore(dir string) *Store {
+	return &Store{dir: dir, keep: DefaultKeep, counts: map[string]int{}}
+}
+
+// WithRetention sets how many snapshots to keep per search, 0 for all
+func (s *Store) WithRetention(keep int) *Store {
+	s.keep = keep
+	return s
+}
+
+var unsafeChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, unsafeChars.ReplaceAllString(name, "_")+".jsonl")
+}
+
+func (s *Store) latestPath(name string) string {
+	return filepath.Join(s.dir, unsafeChars.ReplaceAllString(name, "_")+".latest.json")
+}
+
+// Append records a snapshot at the end of the search's history
+func (s *Store) Append(snap Snapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := writeFile(s.latestPath(snap.Name), data); err != nil {
+		return fmt.Errorf("failed to write latest snapshot: %w", err)
+	}
+
+	count, ok := s.counts[snap.Name]
+	if !ok {
+		snaps, err := s.read(snap.Name)
+		if err != nil {
+			return err
+		}
+		count = len(snaps)
+	}
+
+	f, err := os.OpenFile(s.path(snap.Name), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	_, err = f.Write(append(data, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	count++
+
+	if s.keep > 0 && count > 2*s.keep {
+		if err := s.compact(snap.Name); err != nil {
+			return err
+		}
+		count = s.keep
+	}
+	s.counts[snap.Name] = count
+	return nil
+}
+
+// compact rewrites a search's log with only its most recent snapshots
+func (s *Store) compact(name string) error {
+	snaps, err := s.read(name)
+	if err != nil {
+		return err
+	}
+	if len(snaps) > s.keep {
+		snaps = snaps[len(snaps)-s.keep:]
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, snap := range snaps {
+		if err := encoder.Encode(snap); err != nil {
+			return fmt.Errorf("failed to encode snapshot: %w", err)
+		}
+	}
+	if err := writeFile(s.path(name), buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to compact history: %w", err)
+	}
+	return nil
+}
+
+// Latest returns the most recent snapshot for a search, or nil if there is none
+func (s *Store) Latest(name string) (*Snapshot, error) {
+	data, err := os.ReadFile(s.latestPath(name))
+	switch {
+	case err == nil:
+		var snap Snapshot
+		if err := json.Unmarshal(data, &snap); err != nil {
+			return nil, fmt.Errorf("failed to read latest snapshot: %w", err)
+		}
+		return &snap, nil
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("failed to read latest snapshot: %w", err)
+	}
+
+	// History written before the latest file existed
+	snaps, err := s.read(name)
+	if err != nil || len(snaps) == 0 {
+		return nil, err
+	}
+	return &snaps[len(snaps)-1], nil
+}
+
+// read returns every snapshot in a search's log, oldest first
+func (s *Store) read(name string) ([]Snapshot, error) {
+	f, err := os.Open(s.path(name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open history: %w", err)
+	}
+	defer f.Close()
+
+	var snaps []Snapshot
+	decoder := json.NewDecoder(f)
+	for {
+		var snap Snapshot
+		if err := decoder.Decode(&snap); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("failed to read history: %w", err)
+		}
+		snaps = append(snaps, snap)
+	}
+	return snaps, nil
+}
+
+// writeFile replaces a file through a rename so readers never see it half
+// written
+func writeFile(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Changes returns the current results that are new since prev: a route and
+// date that wasn't seen before, or a cabin that opened up or got cheaper
+func Changes(prev, curr []api.Availability) []api.Availability {
+	seen := make(map[string]api.Availability, len(prev))
+	for _, a := range prev {
+		seen[key(a)] = a
+	}
+
+	var changed []api.Availability
+	for _, a := range curr {
+		old, ok := seen[key(a)]
+		if !ok {
+			changed = append(changed, a)
+			continue
+		}
+		for _, cabin := range api.ValidCabins() {
+			now, before := a.Cabin(cabin), old.Cabin(cabin)
+			if !now.Available {
+				continue
+			}
+			if !before.Available || (now.Miles > 0 && now.Miles < before.Miles) {
+				changed = append(changed, a)
+				break
+			}
+		}
+	}
+
+	return changed
+}
+
+func key(a api.Availability) string {
+	return a.Route.OriginAirport + "-" + a.Route.DestinationAirport + "|" + a.Date + "|" + a.Source
+}
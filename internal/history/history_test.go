@@ -0,0 +1,146 @@
+package history
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+func avail(origin, date, source string, jMiles string) api.Availability {
+	a := api.Availability{
+		Route:  api.Route{OriginAirport: origin, DestinationAirport: "NRT"},
+		Date:   date,
+		Source: source,
+	}
+	if jMiles != "" {
+		a.JAvailable, a.JMileageCost = true, jMiles
+	}
+	return a
+}
+
+func TestChanges(t *testing.T) {
+	prev := []api.Availability{
+		avail("SFO", "2024-06-01", "aeroplan", "75000"),
+		avail("SFO", "2024-06-02", "aeroplan", ""),
+		avail("SFO", "2024-06-03", "aeroplan", "75000"),
+		avail("LAX", "2024-06-01", "united", "80000"),
+	}
+
+	tests := []struct {
+		name string
+		curr api.Availability
+		want bool
+	}{
+		{"unchanged", avail("SFO", "2024-06-01", "aeroplan", "75000"), false},
+		{"new date", avail("SFO", "2024-06-04", "aeroplan", "75000"), true},
+		{"new route", avail("SEA", "2024-06-01", "aeroplan", "75000"), true},
+		{"new program", avail("SFO", "2024-06-01", "united", "75000"), true},
+		{"cabin opened", avail("SFO", "2024-06-02", "aeroplan", "75000"), true},
+		{"cheaper", avail("SFO", "2024-06-03", "aeroplan", "60000"), true},
+		{"pricier", avail("LAX", "2024-06-01", "united", "90000"), false},
+		{"cabin closed", avail("LAX", "2024-06-01", "united", ""), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Changes(prev, []api.Availability{tt.curr})
+			if (len(got) == 1) != tt.want {
+				t.Errorf("Changes = %d results, want changed=%v", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestChangesFromEmpty(t *testing.T) {
+	curr := []api.Availability{avail("SFO", "2024-06-01", "aeroplan", "75000"), avail("SFO", "2024-06-02", "aeroplan", "")}
+	if got := Changes(nil, curr); len(got) != 2 {
+		t.Errorf("Changes(nil) = %d results, want every result", len(got))
+	}
+	if got := Changes(curr, nil); len(got) != 0 {
+		t.Errorf("Changes to nothing = %d results, want none", len(got))
+	}
+}
+
+func TestStore(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	latest, err := store.Latest("tokyo j")
+	if err != nil || latest != nil {
+		t.Fatalf("Latest on empty store = %v, %v", latest, err)
+	}
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		snap := Snapshot{
+			Name:      "tokyo j",
+			FetchedAt: base.Add(time.Duration(i) * time.Hour),
+			Results:   make([]api.Availability, i),
+		}
+		if err := store.Append(snap); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	latest, err = store.Latest("tokyo j")
+	if err != nil {
+		t.Fatalf("Latest: %v", err)
+	}
+	if !latest.FetchedAt.Equal(base.Add(2*time.Hour)) || len(latest.Results) != 2 {
+		t.Errorf("Latest = %s with %d results, want the last snapshot", latest.FetchedAt, len(latest.Results))
+	}
+}
+
+func TestStoreRetention(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir).WithRetention(2)
+
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		snap := Snapshot{Name: "tokyo", FetchedAt: base.Add(time.Duration(i) * time.Hour)}
+		if err := store.Append(snap); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	// The fifth append passes twice the cap, so the log is trimmed to two
+	snaps, err := store.read("tokyo")
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if len(snaps) != 2 || !snaps[1].FetchedAt.Equal(base.Add(4*time.Hour)) {
+		t.Fatalf("log has %d snapshots ending %v, want the last 2", len(snaps), snaps[len(snaps)-1].FetchedAt)
+	}
+
+	// A new store counts the existing log before appending
+	reopened := NewStore(dir).WithRetention(2)
+	for i := 5; i < 7; i++ {
+		if err := reopened.Append(Snapshot{Name: "tokyo", FetchedAt: base.Add(time.Duration(i) * time.Hour)}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	if snaps, _ := reopened.read("tokyo"); len(snaps) != 4 {
+		t.Errorf("log has %d snapshots, want 4 before the next trim", len(snaps))
+	}
+}
+
+func TestLatestWithoutLatestFile(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		if err := store.Append(Snapshot{Name: "tokyo", FetchedAt: base.Add(time.Duration(i) * time.Hour)}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	// History from before the latest file existed falls back to the log
+	if err := os.Remove(store.latestPath("tokyo")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	latest, err := store.Latest("tokyo")
+	if err != nil || latest == nil || !latest.FetchedAt.Equal(base.Add(time.Hour)) {
+		t.Errorf("Latest = %v, %v, want the last logged snapshot", latest, err)
+	}
+}
@@ -0,0 +1,131 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// Compact result shapes keep tool output small: short keys, only available
+// cabins, and routes folded into a single string.
+
+type compactList struct {
+	Total   int         `json:"total"`
+	Shown   int         `json:"shown"`
+	Results interface{} `json:"results"`
+}
+
+type compactCabin struct {
+	Miles    int    `json:"miles"`
+	Seats    int    `json:"seats"`
+	Direct   bool   `json:"direct,omitempty"`
+	Airlines string `json:"airlines,omitempty"`
+}
+
+type compactAvail struct {
+	ID     string                  `json:"id"`
+	Date   string                  `json:"date"`
+	Route  string                  `json:"route"`
+	Source string                  `json:"source"`
+	Cabins map[string]compactCabin `json:"cabins"`
+}
+
+type compactTrip struct {
+	ID       string   `json:"id"`
+	Cabin    string   `json:"cabin"`
+	Route    string   `json:"route"`
+	Flights  []string `json:"flights"`
+	Departs  string   `json:"departs"`
+	Arrives  string   `json:"arrives"`
+	Minutes  int      `json:"minutes"`
+	Stops    int      `json:"stops"`
+	Miles    int      `json:"miles"`
+	Taxes    string   `json:"taxes,omitempty"`
+	Seats    int      `json:"seats"`
+	Aircraft []string `json:"aircraft,omitempty"`
+}
+
+func compactAvailability(data []api.Availability, limit int) compactList {
+	shown := data
+	if len(shown) > limit {
+		shown = shown[:limit]
+	}
+
+	rows := make([]compactAvail, 0, len(shown))
+	for _, a := range shown {
+		row := compactAvail{
+			ID:     a.ID,
+			Date:   a.Date,
+			Route:  a.Route.OriginAirport + "-" + a.Route.DestinationAirport,
+			Source: a.Source,
+			Cabins: map[string]compactCabin{},
+		}
+		for _, code := range api.ValidCabins() {
+			if c := a.Cabin(code); c.Available {
+				row.Cabins[code] = compactCabin{Miles: c.Miles, Seats: c.RemainingSeats, Direct: c.Direct, Airlines: c.Airlines}
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return compactList{Total: len(data), Shown: len(rows), Results: rows}
+}
+
+func compactRoutes(data []api.Route, limit int) compactList {
+	shown := data
+	if len(shown) > limit {
+		shown = shown[:limit]
+	}
+
+	rows := make([]string, 0, len(shown))
+	for _, r := range shown {
+		rows = append(rows, fmt.Sprintf("%s-%s %dmi %s", r.OriginAirport, r.DestinationAirport, r.Distance, r.Source))
+	}
+
+	return compactList{Total: len(data), Shown: len(rows), Results: rows}
+}
+
+func compactTrips(data []api.Trip) compactList {
+	rows := make([]compactTrip, 0, len(data))
+	for _, t := range data {
+		row := compactTrip{
+			ID:      t.ID,
+			Cabin:   t.Cabin,
+			Flights: splitFlights(t.FlightNumbers),
+			Departs: t.DepartsAt.Format("2006-01-02 15:04"),
+			Arrives: t.ArrivesAt.Format("2006-01-02 15:04"),
+			Minutes: t.TotalDuration,
+			Stops:   t.Stops,
+			Miles:   t.MileageCost,
+			Seats:   t.RemainingSeats,
+		}
+		if t.TotalTaxes > 0 {
+			row.Taxes = t.TaxesCurrency + " " + api.FormatTaxes(t.TotalTaxes, t.TaxesCurrency)
+		}
+
+		var airports []string
+		for i, seg := range t.AvailabilitySegments {
+			if i == 0 {
+				airports = append(airports, seg.OriginAirport)
+			}
+			airports = append(airports, seg.DestinationAirport)
+			row.Aircraft = append(row.Aircraft, seg.AircraftCode)
+		}
+		row.Route = strings.Join(airports, "-")
+
+		rows = append(rows, row)
+	}
+
+	return compactList{Total: len(data), Shown: len(rows), Results: rows}
+}
+
+func splitFlights(s string) []string {
+	var flights []string
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			flights = append(flights, f)
+		}
+	}
+	return flights
+}
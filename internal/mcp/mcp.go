@@ -0,0 +1,157 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// ProtocolVersion is the MCP revision this server implements
+const ProtocolVersion = "2024-11-05"
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+// Server speaks the Model Context Protocol over newline-delimited JSON-RPC
+type Server struct {
+	client  *api.Client
+	version string
+	tools   []tool
+
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewServer creates an MCP server backed by the API client
+func NewServer(client *api.Client, version string) *Server {
+	s := &Server{client: client, version: version}
+	s.tools = s.registerTools()
+	return s
+}
+
+// Serve reads requests from r and writes responses to w until r is exhausted
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	s.out = w
+	reader := bufio.NewReader(r)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			s.handleLine(line)
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (s *Server) handleLine(line []byte) {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		s.write(response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: "parse error"}})
+		return
+	}
+
+	result, err := s.dispatch(req)
+
+	// Notifications have no ID and never get a response
+	if len(req.ID) == 0 {
+		return
+	}
+
+	resp := response{JSONRPC: "2.0", ID: req.ID, Result: result}
+	if err != nil {
+		var rpcErr *rpcError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &rpcError{Code: codeInvalidRequest, Message: err.Error()}
+		}
+		resp.Result = nil
+		resp.Error = rpcErr
+	}
+	s.write(resp)
+}
+
+func (s *Server) dispatch(req request) (interface{}, error) {
+	if req.JSONRPC != "2.0" {
+		return nil, &rpcError{Code: codeInvalidRequest, Message: "jsonrpc must be \"2.0\""}
+	}
+
+	switch req.Method {
+	case "initialize":
+		return s.initialize(), nil
+	case "notifications/initialized", "notifications/cancelled":
+		// If sent with an ID by mistake, answer with an empty result
+		return struct{}{}, nil
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return map[string]interface{}{"tools": s.tools}, nil
+	case "tools/call":
+		return s.callTool(req.Params)
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+}
+
+// initialize answers the client handshake; clients on a newer revision are
+// expected to fall back to ProtocolVersion
+func (s *Server) initialize() interface{} {
+	return map[string]interface{}{
+		"protocolVersion": ProtocolVersion,
+		"capabilities": map[string]interface{}{
+			"tools": map[string]interface{}{},
+		},
+		"serverInfo": map[string]string{
+			"name":    "seats",
+			"version": s.version,
+		},
+		"instructions": "Query seats.aero award flight availability. Results are compact JSON; " +
+			"use search for specific airports and dates, bulk_availability for a whole program, " +
+			"routes to explore a program's network, and trip_details with an availability id for flights.",
+	}
+}
+
+func (s *Server) write(resp response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Encode adds the trailing newline that delimits messages
+	json.NewEncoder(s.out).Encode(resp)
+}
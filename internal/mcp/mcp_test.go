@@ -0,0 +1,188 @@
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+type testResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result"`
+	Error   *rpcError       `json:"error"`
+}
+
+// serve runs the server over the given request lines and returns every
+// response it wrote
+func serve(t *testing.T, lines ...string) []testResponse {
+	t.Helper()
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/search":
+			io.WriteString(w, `{"data":[{"ID":"a1","Route":{"OriginAirport":"SFO","DestinationAirport":"NRT"},"Date":"2024-06-01","Source":"aeroplan","JAvailable":true,"JMileageCost":"75000","JRemainingSeats":2}],"count":1}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(upstream.Close)
+
+	s := NewServer(api.NewClient("key").WithBaseURL(upstream.URL), "test")
+	var out strings.Builder
+	if err := s.Serve(strings.NewReader(strings.Join(lines, "\n")+"\n"), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	var responses []testResponse
+	scanner := bufio.NewScanner(strings.NewReader(out.String()))
+	for scanner.Scan() {
+		var resp testResponse
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid response line %q: %v", scanner.Text(), err)
+		}
+		if resp.JSONRPC != "2.0" {
+			t.Errorf("jsonrpc = %q", resp.JSONRPC)
+		}
+		if (resp.Result == nil) == (resp.Error == nil) {
+			t.Errorf("response %s must have exactly one of result and error", scanner.Text())
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func TestInitialize(t *testing.T) {
+	resp := serve(t, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`)
+	if len(resp) != 1 {
+		t.Fatalf("got %d responses, want 1", len(resp))
+	}
+
+	var result struct {
+		ProtocolVersion string            `json:"protocolVersion"`
+		ServerInfo      map[string]string `json:"serverInfo"`
+		Capabilities    map[string]any    `json:"capabilities"`
+	}
+	if err := json.Unmarshal(resp[0].Result, &result); err != nil {
+		t.Fatal(err)
+	}
+	if string(resp[0].ID) != "1" || result.ProtocolVersion != ProtocolVersion || result.ServerInfo["version"] != "test" {
+		t.Errorf("initialize = %s", resp[0].Result)
+	}
+	if _, ok := result.Capabilities["tools"]; !ok {
+		t.Error("tools capability not advertised")
+	}
+}
+
+func TestToolsList(t *testing.T) {
+	resp := serve(t, `{"jsonrpc":"2.0","id":"a","method":"tools/list"}`)
+
+	var result struct {
+		Tools []struct {
+			Name        string         `json:"name"`
+			InputSchema map[string]any `json:"inputSchema"`
+		} `json:"tools"`
+	}
+	if err := json.Unmarshal(resp[0].Result, &result); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+		if tool.InputSchema["type"] != "object" {
+			t.Errorf("%s schema type = %v", tool.Name, tool.InputSchema["type"])
+		}
+	}
+	if got := strings.Join(names, ","); got != "search,bulk_availability,routes,trip_details" {
+		t.Errorf("tools = %s", got)
+	}
+}
+
+func TestToolsCall(t *testing.T) {
+	resp := serve(t,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"search","arguments":{"origin_airports":["sfo"],"destination_airports":["nrt"]}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"search","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"routes","arguments":{"source":"aeroplan"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"teleport"}}`,
+	)
+	if len(resp) != 4 {
+		t.Fatalf("got %d responses, want 4", len(resp))
+	}
+
+	var ok toolResult
+	if err := json.Unmarshal(resp[0].Result, &ok); err != nil {
+		t.Fatal(err)
+	}
+	if ok.IsError || len(ok.Content) != 1 || !strings.Contains(ok.Content[0].Text, `"route":"SFO-NRT"`) {
+		t.Errorf("search result = %s", resp[0].Result)
+	}
+
+	// Missing arguments and upstream failures are tool errors, not RPC errors
+	for _, r := range resp[1:3] {
+		var failed toolResult
+		if err := json.Unmarshal(r.Result, &failed); err != nil {
+			t.Fatalf("id %s: %v", r.ID, err)
+		}
+		if !failed.IsError {
+			t.Errorf("id %s: result = %s, want isError", r.ID, r.Result)
+		}
+	}
+
+	if resp[3].Error == nil || resp[3].Error.Code != codeInvalidParams {
+		t.Errorf("unknown tool = %+v, want invalid params", resp[3].Error)
+	}
+}
+
+func TestNotifications(t *testing.T) {
+	resp := serve(t,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":1}}`,
+		`{"jsonrpc":"2.0","method":"notifications/unknown"}`,
+		`{"jsonrpc":"2.0","id":7,"method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":8,"method":"ping"}`,
+	)
+
+	// Only the requests with an ID are answered
+	if len(resp) != 2 {
+		t.Fatalf("got %d responses, want 2", len(resp))
+	}
+	if string(resp[0].ID) != "7" || string(resp[0].Result) != "{}" {
+		t.Errorf("notification with id = %+v, want an empty result", resp[0])
+	}
+	if string(resp[1].ID) != "8" || string(resp[1].Result) != "{}" {
+		t.Errorf("ping = %+v", resp[1])
+	}
+}
+
+func TestErrors(t *testing.T) {
+	resp := serve(t,
+		`{"jsonrpc":"2.0","id":1,`,
+		`{"jsonrpc":"1.0","id":2,"method":"ping"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"resources/list"}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":"search"}`,
+	)
+
+	want := []struct {
+		id   string
+		code int
+	}{
+		{"null", codeParseError},
+		{"2", codeInvalidRequest},
+		{"3", codeMethodNotFound},
+		{"4", codeInvalidParams},
+	}
+	if len(resp) != len(want) {
+		t.Fatalf("got %d responses, want %d", len(resp), len(want))
+	}
+	for i, w := range want {
+		if string(resp[i].ID) != w.id || resp[i].Error == nil || resp[i].Error.Code != w.code {
+			t.Errorf("response %d = id %s error %+v, want id %s code %d", i, resp[i].ID, resp[i].Error, w.id, w.code)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package mcp
+
+import (
+	"reflect"
+	"strings"
+)
+
+// paginationFields are handled by the tools themselves and not exposed
+var paginationFields = map[string]bool{"take": true, "skip": true, "cursor": true}
+
+// schemaFor derives a JSON schema for a params struct from its json and desc tags
+func schemaFor(v interface{}, required ...string) map[string]interface{} {
+	properties := map[string]interface{}{}
+
+	t := reflect.TypeOf(v)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" || paginationFields[name] {
+			continue
+		}
+
+		prop := map[string]interface{}{"type": jsonType(field.Type)}
+		if field.Type.Kind() == reflect.Slice {
+			prop["items"] = map[string]interface{}{"type": jsonType(field.Type.Elem())}
+		}
+		if desc := field.Tag.Get("desc"); desc != "" {
+			prop["description"] = desc
+		}
+		properties[name] = prop
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
@@ -0,0 +1,209 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// defaultLimit caps the number of results returned to conserve tokens
+const defaultLimit = 50
+
+// tool is an MCP tool definition plus its handler
+type tool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+
+	handler func(args json.RawMessage) (interface{}, error)
+}
+
+// toolResult is the MCP tools/call result
+type toolResult struct {
+	Content []content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+type content struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func (s *Server) registerTools() []tool {
+	return []tool{
+		{
+			Name:        "search",
+			Description: "Search cached award availability between airports over a date range. Returns one compact row per route, date and program with the available cabins.",
+			InputSchema: withLimit(schemaFor(api.SearchParams{}, "origin_airports", "destination_airports")),
+			handler:     s.search,
+		},
+		{
+			Name:        "bulk_availability",
+			Description: "Bulk award availability for one mileage program, optionally filtered by cabin, regions and dates.",
+			InputSchema: withLimit(schemaFor(api.AvailabilityParams{}, "source")),
+			handler:     s.bulkAvailability,
+		},
+		{
+			Name:        "routes",
+			Description: "List the routes a mileage program has availability data for, optionally from one origin.",
+			InputSchema: withLimit(schemaFor(api.RoutesParams{})),
+			handler:     s.routes,
+		},
+		{
+			Name:        "trip_details",
+			Description: "Flight-level trip details (segments, times, taxes) for an availability id returned by search or bulk_availability.",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"availability_id": map[string]interface{}{
+						"type":        "string",
+						"description": "Availability id from a search or bulk_availability result",
+					},
+				},
+				"required": []string{"availability_id"},
+			},
+			handler: s.tripDetails,
+		},
+	}
+}
+
+// withLimit adds the result limit property shared by list tools
+func withLimit(schema map[string]interface{}) map[string]interface{} {
+	schema["properties"].(map[string]interface{})["limit"] = map[string]interface{}{
+		"type":        "integer",
+		"description": fmt.Sprintf("Maximum results to return (default %d)", defaultLimit),
+	}
+	return schema
+}
+
+func (s *Server) callTool(params json.RawMessage) (interface{}, error) {
+	var p struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "invalid tools/call params"}
+	}
+	if len(p.Arguments) == 0 {
+		p.Arguments = json.RawMessage("{}")
+	}
+
+	for _, t := range s.tools {
+		if t.Name != p.Name {
+			continue
+		}
+
+		// Tool failures are reported in the result so the model can see them
+		result, err := t.handler(p.Arguments)
+		if err != nil {
+			return toolResult{Content: []content{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+		}
+		text, err := json.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+		return toolResult{Content: []content{{Type: "text", Text: string(text)}}}, nil
+	}
+
+	return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool: %s", p.Name)}
+}
+
+// limitArg reads the optional limit argument
+func limitArg(args json.RawMessage) int {
+	var l struct {
+		Limit int `json:"limit"`
+	}
+	json.Unmarshal(args, &l)
+	if l.Limit <= 0 {
+		return defaultLimit
+	}
+	return l.Limit
+}
+
+func (s *Server) search(args json.RawMessage) (interface{}, error) {
+	var params api.SearchParams
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if len(params.OriginAirports) == 0 || len(params.DestinationAirports) == 0 {
+		return nil, fmt.Errorf("origin_airports and destination_airports are required")
+	}
+
+	params.OriginAirports = normalize(params.OriginAirports, strings.ToUpper)
+	params.DestinationAirports = normalize(params.DestinationAirports, strings.ToUpper)
+	params.Sources = normalize(params.Sources, strings.ToLower)
+	params.Cabin = api.CabinParam(params.Cabin)
+	params.Take, params.Skip, params.Cursor = 0, 0, 0
+
+	resp, err := s.client.Search(params)
+	if err != nil {
+		return nil, err
+	}
+	return compactAvailability(resp.Data, limitArg(args)), nil
+}
+
+func (s *Server) bulkAvailability(args json.RawMessage) (interface{}, error) {
+	var params api.AvailabilityParams
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if params.Source == "" {
+		return nil, fmt.Errorf("source is required")
+	}
+
+	params.Source = strings.ToLower(params.Source)
+	params.Cabin = api.CabinParam(params.Cabin)
+	params.Take, params.Skip, params.Cursor = 0, 0, 0
+
+	resp, err := s.client.GetAvailability(params)
+	if err != nil {
+		return nil, err
+	}
+	return compactAvailability(resp.Data, limitArg(args)), nil
+}
+
+func (s *Server) routes(args json.RawMessage) (interface{}, error) {
+	var params api.RoutesParams
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	params.Source = strings.ToLower(params.Source)
+	params.Origin = strings.ToUpper(params.Origin)
+
+	resp, err := s.client.GetRoutes(params)
+	if err != nil {
+		return nil, err
+	}
+	return compactRoutes(resp.Data, limitArg(args)), nil
+}
+
+func (s *Server) tripDetails(args json.RawMessage) (interface{}, error) {
+	var p struct {
+		AvailabilityID string `json:"availability_id"`
+	}
+	if err := json.Unmarshal(args, &p); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if p.AvailabilityID == "" {
+		return nil, fmt.Errorf("availability_id is required")
+	}
+
+	resp, err := s.client.GetTrips(p.AvailabilityID)
+	if err != nil {
+		return nil, err
+	}
+	return compactTrips(resp.Data), nil
+}
+
+func normalize(values []string, fn func(string) string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, fn(v))
+		}
+	}
+	return result
+}
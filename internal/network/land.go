@@ -0,0 +1,155 @@
+package network
+
+// landPolygons are coarse coastline outlines as [lon, lat] vertices, detailed
+// enough to recognize the continents on a terminal-sized map
+var landPolygons = [][][2]float64{
+	// North America
+	{
+		{-168, 66}, {-162, 70}, {-156, 71.5}, {-140, 70}, {-128, 70}, {-115, 68}, {-95, 72},
+		{-94, 60}, {-88, 56}, {-82, 55}, {-79, 52}, {-77, 60}, {-78, 62.5}, {-72, 62},
+		{-65, 60}, {-61, 56}, {-55, 52}, {-60, 47.5}, {-66, 45}, {-70, 43.5}, {-70, 41.5},
+		{-74, 40.5}, {-76, 35}, {-81, 31}, {-80, 25.2}, {-82.5, 28}, {-84, 30}, {-90, 29},
+		{-97, 28}, {-97.5, 22}, {-94, 18.5}, {-91, 19}, {-90.5, 21}, {-87, 21.5}, {-88, 16},
+		{-83.5, 15}, {-83.5, 11}, {-79.5, 9}, {-77.5, 8.5}, {-80, 7.5}, {-83, 8}, {-86, 11},
+		{-92, 14.5}, {-96, 15.7}, {-105.5, 20}, {-105.5, 23}, {-112, 29}, {-114.5, 31},
+		{-110, 23}, {-114, 28}, {-117, 32.5}, {-120.5, 34.5}, {-124, 40}, {-124, 46},
+		{-123, 49}, {-130, 54}, {-135, 58}, {-140, 60}, {-150, 61}, {-158, 57}, {-165, 54.5},
+		{-158, 58.5}, {-162, 60}, {-165, 62}, {-165, 65},
+	},
+	// Canadian Arctic islands
+	{
+		{-120, 70}, {-125, 74}, {-115, 77}, {-95, 80}, {-75, 83}, {-62, 82.5}, {-70, 79},
+		{-78, 76}, {-72, 71}, {-62, 67}, {-65, 63}, {-75, 64}, {-85, 70}, {-100, 72},
+	},
+	// Greenland
+	{
+		{-73, 78}, {-60, 82}, {-35, 83.5}, {-20, 82}, {-18, 77}, {-22, 70}, {-32, 68},
+		{-40, 65}, {-43, 60}, {-50, 62}, {-53, 66}, {-55, 70}, {-58, 75}, {-68, 76.5},
+	},
+	// Iceland
+	{{-24, 65.5}, {-22, 66.4}, {-16, 66.5}, {-13.5, 65}, {-18, 63.4}, {-22.5, 63.8}},
+	// Cuba and Hispaniola
+	{{-85, 21.8}, {-81.5, 23.2}, {-77, 22}, {-74.2, 20.2}, {-78, 19.8}, {-84, 21.8}},
+	{{-74.5, 18.4}, {-72.8, 19.9}, {-69.5, 19.7}, {-68.4, 18.5}, {-71.4, 17.6}},
+	// South America
+	{
+		{-77.5, 8.5}, {-72, 12}, {-62, 10.5}, {-52, 5}, {-50, 0}, {-44, -2.5}, {-35, -5},
+		{-35, -9}, {-39, -15}, {-41, -22}, {-48, -26}, {-53, -34}, {-58, -38}, {-62, -41},
+		{-65, -45}, {-68, -50}, {-68.5, -54}, {-73, -53}, {-75, -47}, {-73.5, -40},
+		{-71.5, -30}, {-70.3, -18}, {-76, -14}, {-81, -6}, {-80, -2}, {-80, 1}, {-78.5, 4},
+	},
+	// Europe and Asia
+	{
+		{-9, 43.5}, {-1.5, 43.5}, {-1.5, 46.5}, {-4.5, 48}, {-1.5, 49.5}, {2, 51}, {4.5, 52.5},
+		{8.5, 54}, {8.5, 57}, {10.5, 57.7}, {10.5, 55}, {12.5, 54.5}, {18, 54.8}, {21, 55},
+		{21, 57}, {24, 57.5}, {24, 59.3}, {29, 60}, {22, 60.5}, {21.5, 63}, {25, 65.5},
+		{22, 65.8}, {18, 63}, {17, 61}, {19, 59.8}, {16.5, 57}, {14, 55.5}, {12.5, 56},
+		{11, 58.5}, {10, 59}, {8, 58}, {5.5, 58.5}, {5, 62}, {10, 64}, {14, 67.5}, {17, 69.5},
+		{23, 70.5}, {30, 70}, {33, 69}, {40, 67.5}, {41, 66.5}, {44, 68.5}, {53, 68.5},
+		{60, 69.5}, {69, 73}, {80, 73.5}, {87, 75}, {100, 77.5}, {105, 77.5}, {113, 73.5},
+		{128, 72}, {140, 72.5}, {150, 71}, {160, 70}, {170, 70}, {180, 69}, {180, 65},
+		{178, 64.5}, {175, 62}, {170, 60}, {163, 58}, {162, 56}, {156, 51}, {156, 57},
+		{160, 61.5}, {154, 59.3}, {143, 59.3}, {137, 54}, {140, 52}, {141, 48}, {138, 46},
+		{135, 43}, {130, 42.5}, {129.5, 36}, {126.5, 34.5}, {126, 37.5}, {125, 39.5},
+		{121.5, 39}, {118, 39}, {122, 37}, {120.5, 36}, {122, 31}, {121.5, 28}, {119, 25},
+		{116, 22.8}, {111, 21.5}, {108, 21.5}, {106, 20}, {106, 17.5}, {109, 12}, {109, 11},
+		{105, 8.6}, {104.5, 10.5}, {101, 12.7}, {100, 13.5}, {99.5, 10}, {100.5, 7},
+		{103.5, 1.3}, {101, 2.8}, {98.3, 8}, {98.5, 13}, {97.5, 16.5}, {94.5, 16}, {94, 19},
+		{92, 22}, {90, 22}, {87, 21.5}, {86.5, 20}, {80, 15.5}, {80, 10}, {77.5, 8},
+		{76.5, 9.5}, {73, 17}, {72.5, 21.5}, {70, 22.5}, {68, 23.5}, {66.5, 25.5},
+		{61.5, 25.2}, {57.5, 25.7}, {56, 27}, {52, 27.8}, {50, 30}, {48, 29.5}, {50.2, 26.5},
+		{51.5, 24.5}, {54, 24.2}, {56.2, 26.3}, {56.5, 24}, {59.8, 22.5}, {57.8, 19},
+		{55, 17}, {52, 16}, {45, 13}, {43.3, 12.6}, {42.5, 15.5}, {39, 21.5}, {35, 28},
+		{34.5, 28}, {32.6, 30}, {32.3, 31.2}, {34.3, 31.3}, {35.5, 33.5}, {36, 36.7},
+		{32.5, 36.1}, {29.5, 36.3}, {27.3, 37}, {26.3, 39.5}, {26, 40.8}, {24, 40.8},
+		{23, 40.2}, {24, 38}, {23, 36.5}, {21.7, 36.8}, {21, 38.5}, {19.5, 40}, {19.5, 41.8},
+		{16, 43.5}, {13.6, 45.7}, {12.3, 45.4}, {12.5, 44}, {14, 42.5}, {16, 41.5},
+		{18.5, 40.2}, {17, 39}, {16, 38}, {15.6, 38.2}, {15.8, 40}, {14, 41}, {12, 41.9},
+		{10.5, 43}, {9, 44.4}, {7, 43.7}, {3, 43.2}, {3.2, 42}, {0.5, 40.5}, {-0.3, 39.5},
+		{0, 38.6}, {-2, 36.8}, {-5.6, 36}, {-6.5, 37}, {-8.9, 37}, {-8.9, 38.7}, {-9.5, 39.5},
+		{-8.7, 42},
+	},
+	// Chukotka, east of the antimeridian
+	{{-180, 69}, {-172, 66.5}, {-170, 65.5}, {-175, 64.5}, {-180, 65}},
+	// Great Britain and Ireland
+	{
+		{-5.7, 50}, {1.5, 51}, {1.7, 52.7}, {0, 53.5}, {-1.5, 55}, {-2, 57}, {-3.3, 58.6},
+		{-5, 58.6}, {-6.2, 56.5}, {-5, 55}, {-3, 54}, {-4.7, 53.3}, {-4.5, 52}, {-5.3, 51.7},
+		{-4, 51.3},
+	},
+	{{-6, 52}, {-6, 54}, {-7.3, 55.3}, {-10, 54}, {-10, 51.6}},
+	// Svalbard and Novaya Zemlya
+	{{11, 78.5}, {16, 80}, {27, 80}, {22, 77.5}, {17, 76.5}},
+	{{52, 71.5}, {56, 75}, {68, 77}, {60, 75}, {55, 70.8}},
+	// Africa
+	{
+		{-5.9, 35.8}, {-1, 35.5}, {3, 36.8}, {10, 37.2}, {11, 35.2}, {10, 34}, {11, 33.2},
+		{15.2, 32.3}, {20, 30.8}, {20, 32.3}, {23, 32.6}, {25, 31.7}, {29, 30.9}, {32.3, 31.2},
+		{34.3, 31.3}, {34.9, 29.5}, {33.6, 27.5}, {35.5, 24}, {37.3, 21}, {38.6, 18},
+		{39.7, 15.1}, {43.3, 12.4}, {44.5, 10.4}, {51.3, 11.8}, {51, 10.4}, {48.5, 5},
+		{43, -0.6}, {39.5, -4.5}, {40.2, -10.5}, {40.5, -15}, {35.5, -23}, {32.9, -26},
+		{32.5, -28.5}, {30, -31.3}, {25.7, -34}, {20, -34.8}, {18.3, -34}, {18, -31.5},
+		{15.2, -27}, {14.5, -22.5}, {11.8, -17.3}, {13.5, -11.5}, {12.2, -6}, {9.3, -1},
+		{9.5, 3}, {8.5, 4.5}, {4.5, 6.3}, {1, 5.8}, {-2, 4.8}, {-7.5, 4.4}, {-11.5, 7},
+		{-13.3, 9}, {-16.7, 12.4}, {-17.5, 14.7}, {-16, 19}, {-17, 21}, {-15, 25},
+		{-13, 27.7}, {-9.8, 29.5}, {-9.5, 32.5}, {-6.8, 34},
+	},
+	// Madagascar and Sri Lanka
+	{{49.3, -12}, {50.5, -15.5}, {47, -25}, {45, -25.5}, {43.5, -22}, {44.5, -16.2}, {47, -15.5}},
+	{{79.8, 6}, {80, 9.8}, {82, 7.5}, {81, 6}},
+	// Japan
+	{
+		{130, 31}, {131.5, 31.5}, {132, 33.8}, {135, 33.5}, {136.8, 34.3}, {140, 35}, {141, 38},
+		{142, 40}, {141.3, 41.4}, {140, 40.5}, {139.8, 38}, {137, 37}, {136, 35.8},
+		{132.5, 35.5}, {130.9, 34}, {129.8, 33},
+	},
+	{{140, 41.5}, {141, 43}, {141.8, 45.4}, {145.5, 43.3}, {143, 42}},
+	// Taiwan and the Philippines
+	{{120.1, 23}, {121.9, 25.2}, {121, 22}},
+	{{120, 18.5}, {122.3, 18.5}, {122, 14}, {124, 13}, {121, 13.7}, {120, 16}},
+	{{122, 7}, {125.5, 9.5}, {126.5, 7}, {125.3, 5.6}},
+	// Indonesia and New Guinea
+	{{109, 1.5}, {111, 1.8}, {115, 5}, {117, 7}, {119, 5}, {118, 1}, {117, -1}, {116, -4}, {110, -3}},
+	{{95.3, 5.6}, {98, 4}, {104, -2}, {106, -6}, {104.5, -5.8}, {101, -2}, {98, 1.5}},
+	{{105.5, -6.8}, {106, -6}, {111, -6.5}, {114.5, -7.7}, {111, -8.2}},
+	{{119, -5.5}, {120, 0.7}, {125, 1.5}, {121, -1}, {122, -5}},
+	{
+		{131, -1}, {135, -3.3}, {138, -1.5}, {144, -3.8}, {147, -6}, {150, -10.5}, {146, -8.5},
+		{143, -9.2}, {138, -8.3}, {137.5, -5}, {133, -4},
+	},
+	// Australia and Tasmania
+	{
+		{114, -22}, {114.2, -26}, {115, -34}, {118, -35}, {123.5, -34}, {126, -32.2},
+		{131, -31.5}, {135, -34.8}, {138, -35.6}, {140, -38}, {146, -39}, {150, -37.5},
+		{153, -32}, {153.5, -28}, {153, -25}, {149, -20}, {146, -19}, {145.3, -15},
+		{143.5, -14}, {142.5, -10.7}, {141.5, -13}, {141.7, -16.5}, {140.8, -17.5}, {139, -17},
+		{136.5, -15.5}, {136.8, -12.2}, {132.5, -11.5}, {130, -13}, {129.5, -15}, {126, -14},
+		{122, -17.5}, {121, -19.5}, {116.5, -20.7},
+	},
+	{{144.6, -40.7}, {148.3, -40.9}, {147.8, -43.2}, {146, -43.6}},
+	// New Zealand
+	{{172.7, -34.4}, {174.5, -36}, {178.5, -37.7}, {177, -39.5}, {175, -41.6}, {174.5, -39.5}, {173.8, -39.2}, {174.4, -37}},
+	{{172.7, -40.5}, {174.2, -41.7}, {172.7, -43.5}, {171, -45.5}, {169, -46.6}, {166.5, -46}, {168, -44}, {171, -42}},
+}
+
+// isLand reports whether a point falls inside any land outline
+func isLand(lon, lat float64) bool {
+	for _, poly := range landPolygons {
+		if inPolygon(poly, lon, lat) {
+			return true
+		}
+	}
+	return false
+}
+
+// inPolygon is the even-odd ray casting test
+func inPolygon(poly [][2]float64, lon, lat float64) bool {
+	inside := false
+	for i, j := 0, len(poly)-1; i < len(poly); j, i = i, i+1 {
+		a, b := poly[i], poly[j]
+		if (a[1] > lat) != (b[1] > lat) && lon < (b[0]-a[0])*(lat-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+	}
+	return inside
+}
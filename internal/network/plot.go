@@ -0,0 +1,203 @@
+package network
+
+import (
+	"math"
+	"strings"
+
+	"github.com/JHill6253/seats-aero-cli/internal/airports"
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// CellKind is what a map cell shows, so callers can color it
+type CellKind int
+
+const (
+	Water CellKind = iota
+	Land
+	Path
+	Destination
+	Origin
+	Label
+)
+
+// Cell is one character of a plotted map
+type Cell struct {
+	Char rune
+	Kind CellKind
+}
+
+// Plot is a world map drawn as rows of characters
+type Plot struct {
+	Rows [][]Cell
+}
+
+// String renders the plot without colors
+func (p Plot) String() string {
+	var b strings.Builder
+	for _, row := range p.Rows {
+		for _, c := range row {
+			b.WriteRune(c.Char)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// The map skips the poles, where there are no routes to show
+const (
+	mapNorth = 84.0
+	mapSouth = -58.0
+)
+
+var cellChars = map[CellKind]rune{
+	Water:       ' ',
+	Land:        '.',
+	Path:        '*',
+	Destination: 'o',
+	Origin:      '@',
+}
+
+// plotter maps coordinates to cells on an equirectangular projection
+// centered on a longitude
+type plotter struct {
+	width, height int
+	center        float64
+	rows          [][]Cell
+}
+
+// PlotRoutes draws routes as great-circle paths on a world map width
+// characters wide, centered on the given longitude so paths from an origin
+// there don't wrap. Airports without bundled coordinates are returned as
+// missing.
+func PlotRoutes(routes []api.Route, width int, center float64) (Plot, []string) {
+	if width < 40 {
+		width = 40
+	}
+	// Terminal cells are about twice as tall as they are wide
+	height := int(math.Round(float64(width) * (mapNorth - mapSouth) / 360 / 2))
+
+	p := &plotter{width: width, height: height, center: center}
+	p.rows = make([][]Cell, height)
+	for r := range p.rows {
+		p.rows[r] = make([]Cell, width)
+		lat := mapNorth - (float64(r)+0.5)*(mapNorth-mapSouth)/float64(height)
+		for c := range p.rows[r] {
+			kind := Water
+			if isLand(p.lon(c), lat) {
+				kind = Land
+			}
+			p.rows[r][c] = Cell{Char: cellChars[kind], Kind: kind}
+		}
+	}
+
+	missing := map[string]bool{}
+	type stop struct {
+		airport airports.Airport
+		kind    CellKind
+	}
+	var stops []stop
+	seen := map[string]bool{}
+
+	for _, r := range routes {
+		from, okFrom := airports.Lookup(r.OriginAirport)
+		to, okTo := airports.Lookup(r.DestinationAirport)
+		if !okFrom {
+			missing[r.OriginAirport] = true
+		}
+		if !okTo {
+			missing[r.DestinationAirport] = true
+		}
+		if !okFrom || !okTo {
+			continue
+		}
+
+		n := int(airports.Distance(from, to)/50) + 2
+		for _, pt := range airports.GreatCircle(from, to, n) {
+			p.set(pt[0], pt[1], Path)
+		}
+		for _, s := range []stop{{from, Origin}, {to, Destination}} {
+			if !seen[s.airport.IATA] {
+				seen[s.airport.IATA] = true
+				stops = append(stops, s)
+			}
+		}
+	}
+
+	// Origins go last so they win shared cells, then labels fill free space
+	for _, kind := range []CellKind{Destination, Origin} {
+		for _, s := range stops {
+			if s.kind == kind {
+				p.set(s.airport.Lon, s.airport.Lat, kind)
+			}
+		}
+	}
+	for _, s := range stops {
+		p.label(s.airport)
+	}
+
+	return Plot{Rows: p.rows}, keys(missing)
+}
+
+// lon returns the longitude at the center of a column
+func (p *plotter) lon(col int) float64 {
+	lon := p.center - 180 + (float64(col)+0.5)*360/float64(p.width)
+	return math.Mod(lon+540, 360) - 180
+}
+
+// cell returns the row and column for a point, or false if it is off the map
+func (p *plotter) cell(lon, lat float64) (int, int, bool) {
+	if lat > mapNorth || lat < mapSouth {
+		return 0, 0, false
+	}
+	rel := math.Mod(lon-p.center+540, 360) // 0..360 from the left edge
+	col := int(rel / 360 * float64(p.width))
+	row := int((mapNorth - lat) / (mapNorth - mapSouth) * float64(p.height))
+	if col >= p.width {
+		col = p.width - 1
+	}
+	if row >= p.height {
+		row = p.height - 1
+	}
+	return row, col, true
+}
+
+func (p *plotter) set(lon, lat float64, kind CellKind) {
+	row, col, ok := p.cell(lon, lat)
+	if !ok {
+		return
+	}
+	if cur := p.rows[row][col].Kind; cur == Origin || (cur == Destination && kind == Path) {
+		return
+	}
+	p.rows[row][col] = Cell{Char: cellChars[kind], Kind: kind}
+}
+
+// label writes an airport code beside its marker, to the right if there is
+// room and otherwise to the left, skipping it when both sides are taken
+func (p *plotter) label(a airports.Airport) {
+	row, col, ok := p.cell(a.Lon, a.Lat)
+	if !ok {
+		return
+	}
+	text := []rune(a.IATA)
+	for _, start := range []int{col + 1, col - len(text)} {
+		if p.free(row, start, len(text)) {
+			for i, ch := range text {
+				p.rows[row][start+i] = Cell{Char: ch, Kind: Label}
+			}
+			return
+		}
+	}
+}
+
+func (p *plotter) free(row, start, n int) bool {
+	if start < 0 || start+n > p.width {
+		return false
+	}
+	for c := start; c < start+n; c++ {
+		if k := p.rows[row][c].Kind; k != Water && k != Land {
+			return false
+		}
+	}
+	return true
+}
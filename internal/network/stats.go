@@ -0,0 +1,218 @@
+package network
+
+import (
+	"sort"
+
+	"github.com/JHill6253/seats-aero-cli/internal/airports"
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// Hub is an airport ranked by how many airports it connects to
+type Hub struct {
+	Airport  string `json:"airport"`
+	City     string `json:"city,omitempty"`
+	Country  string `json:"country,omitempty"`
+	Degree   int    `json:"degree"` // distinct airports served to or from
+	Outbound int    `json:"outbound"`
+	Inbound  int    `json:"inbound"`
+}
+
+// Country counts the airports and route endpoints in one country
+type Country struct {
+	Country  string `json:"country"`
+	Airports int    `json:"airports"`
+	Routes   int    `json:"routes"` // routes touching the country
+}
+
+// Bucket is a distance band in the distribution
+type Bucket struct {
+	Label string `json:"label"`
+	Min   int    `json:"min"`
+	Max   int    `json:"max,omitempty"` // exclusive; 0 for the open-ended band
+	Count int    `json:"count"`
+}
+
+// Stats summarizes a program's route network
+type Stats struct {
+	Routes          int         `json:"routes"`
+	Airports        int         `json:"airports"`
+	Sources         []string    `json:"sources"`
+	Hubs            []Hub       `json:"hubs"`
+	Countries       []Country   `json:"countries"`
+	Distances       []Bucket    `json:"distances"`
+	AverageDistance int         `json:"averageDistance"`
+	MedianDistance  int         `json:"medianDistance"`
+	Longest         []api.Route `json:"longest"`
+	Shortest        []api.Route `json:"shortest"`
+	Unknown         []string    `json:"unknownAirports,omitempty"` // airports without bundled data
+}
+
+// distanceBands are the distance distribution buckets, roughly short, medium,
+// long and ultra-long haul
+var distanceBands = []Bucket{
+	{Label: "< 500 mi", Min: 0, Max: 500},
+	{Label: "500-1,500 mi", Min: 500, Max: 1500},
+	{Label: "1,500-3,000 mi", Min: 1500, Max: 3000},
+	{Label: "3,000-5,000 mi", Min: 3000, Max: 5000},
+	{Label: "5,000-7,500 mi", Min: 5000, Max: 7500},
+	{Label: "7,500+ mi", Min: 7500},
+}
+
+// Summarize computes network statistics for routes. Hubs, longest and
+// shortest are limited to top entries; countries cover every airport found
+// in the bundled airport data.
+func Summarize(routes []api.Route, top int) Stats {
+	stats := Stats{Routes: len(routes)}
+	stats.Distances = append([]Bucket(nil), distanceBands...)
+
+	hubs := map[string]*Hub{}
+	neighbors := map[string]map[string]bool{}
+	countries := map[string]*Country{}
+	sources := map[string]bool{}
+	unknown := map[string]bool{}
+
+	hub := func(code string) *Hub {
+		h, ok := hubs[code]
+		if !ok {
+			h = &Hub{Airport: code}
+			if a, ok := airports.Lookup(code); ok {
+				h.City, h.Country = a.City, a.Country
+			} else {
+				unknown[code] = true
+			}
+			hubs[code] = h
+			neighbors[code] = map[string]bool{}
+		}
+		return h
+	}
+
+	var distances []int
+	var measured []api.Route
+	for _, r := range routes {
+		from, to := hub(r.OriginAirport), hub(r.DestinationAirport)
+		from.Outbound++
+		to.Inbound++
+		neighbors[from.Airport][to.Airport] = true
+		neighbors[to.Airport][from.Airport] = true
+		if r.Source != "" {
+			sources[r.Source] = true
+		}
+
+		touched := map[string]bool{}
+		for _, h := range []*Hub{from, to} {
+			if h.Country != "" && !touched[h.Country] {
+				touched[h.Country] = true
+				country(countries, h.Country).Routes++
+			}
+		}
+
+		// Routes with no known distance stay out of the distance figures
+		d := RouteDistance(r)
+		if d == 0 {
+			continue
+		}
+		distances = append(distances, d)
+		measured = append(measured, r)
+		for i := range stats.Distances {
+			b := &stats.Distances[i]
+			if d >= b.Min && (b.Max == 0 || d < b.Max) {
+				b.Count++
+				break
+			}
+		}
+	}
+
+	for code, h := range hubs {
+		h.Degree = len(neighbors[code])
+		stats.Hubs = append(stats.Hubs, *h)
+		if h.Country != "" {
+			country(countries, h.Country).Airports++
+		}
+	}
+	stats.Airports = len(hubs)
+	sort.Slice(stats.Hubs, func(i, j int) bool {
+		a, b := stats.Hubs[i], stats.Hubs[j]
+		if a.Degree != b.Degree {
+			return a.Degree > b.Degree
+		}
+		return a.Airport < b.Airport
+	})
+	stats.Hubs = limit(stats.Hubs, top)
+
+	for _, c := range countries {
+		stats.Countries = append(stats.Countries, *c)
+	}
+	sort.Slice(stats.Countries, func(i, j int) bool {
+		a, b := stats.Countries[i], stats.Countries[j]
+		if a.Routes != b.Routes {
+			return a.Routes > b.Routes
+		}
+		return a.Country < b.Country
+	})
+
+	stats.Sources = keys(sources)
+	stats.Unknown = keys(unknown)
+
+	if len(distances) > 0 {
+		total := 0
+		for _, d := range distances {
+			total += d
+		}
+		stats.AverageDistance = total / len(distances)
+
+		sorted := append([]int(nil), distances...)
+		sort.Ints(sorted)
+		stats.MedianDistance = sorted[len(sorted)/2]
+	}
+
+	byDistance := measured
+	sort.SliceStable(byDistance, func(i, j int) bool { return RouteDistance(byDistance[i]) > RouteDistance(byDistance[j]) })
+	stats.Longest = limit(byDistance, top)
+
+	shortest := make([]api.Route, 0, len(byDistance))
+	for i := len(byDistance) - 1; i >= 0; i-- {
+		shortest = append(shortest, byDistance[i])
+	}
+	stats.Shortest = limit(shortest, top)
+
+	return stats
+}
+
+// RouteDistance returns a route's distance in miles, computing it from the
+// bundled coordinates when the API leaves it empty
+func RouteDistance(r api.Route) int {
+	if r.Distance > 0 {
+		return r.Distance
+	}
+	from, okFrom := airports.Lookup(r.OriginAirport)
+	to, okTo := airports.Lookup(r.DestinationAirport)
+	if !okFrom || !okTo {
+		return 0
+	}
+	return int(airports.Distance(from, to) + 0.5)
+}
+
+func country(countries map[string]*Country, code string) *Country {
+	c, ok := countries[code]
+	if !ok {
+		c = &Country{Country: code}
+		countries[code] = c
+	}
+	return c
+}
+
+func keys(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func limit[T any](items []T, n int) []T {
+	if n > 0 && len(items) > n {
+		return items[:n]
+	}
+	return items
+}
@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"text/template"
+)
+
+// Command runs a local program with the message as JSON on stdin. A
+// configured template renders the message body, as for webhooks.
+type Command struct {
+	name    string
+	command string
+	args    []string
+	tmpl    *template.Template
+}
+
+// Name returns the notifier name
+func (c *Command) Name() string { return c.name }
+
+// Notify runs the command and waits for it to exit
+func (c *Command) Notify(msg Message) error {
+	body, err := render(c.tmpl, msg)
+	if err != nil {
+		return err
+	}
+	msg.Body = body
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(c.command, c.args...)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if out := strings.TrimSpace(stderr.String()); out != "" {
+			return fmt.Errorf("command failed: %w: %s", err, out)
+		}
+		return fmt.Errorf("command failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package notify
+
+import (
+	"fmt"
+	"mime"
+	"net/smtp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Email sends the message through an SMTP server
+type Email struct {
+	name     string
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+	to       []string
+	tmpl     *template.Template
+}
+
+// Name returns the notifier name
+func (e *Email) Name() string { return e.name }
+
+// Notify sends the message as a plain-text email
+func (e *Email) Notify(msg Message) error {
+	body, err := render(e.tmpl, msg)
+	if err != nil {
+		return err
+	}
+
+	subject := msg.Title
+	if subject == "" {
+		subject = "seats.aero alert"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", e.from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(e.to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", headerValue(subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	// Only authenticate when credentials are configured (e.g. local relays)
+	var auth smtp.Auth
+	if e.username != "" {
+		auth = smtp.PlainAuth("", e.username, e.password, e.host)
+	}
+
+	if err := smtp.SendMail(e.addr, auth, e.from, e.to, []byte(b.String())); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// headerValue folds line breaks out of a header so a title can't inject
+// extra headers, and encodes non-ASCII text
+func headerValue(s string) string {
+	s = strings.Join(strings.FieldsFunc(s, func(r rune) bool { return r == '\r' || r == '\n' }), " ")
+	return mime.QEncoding.Encode("utf-8", s)
+}
@@ -0,0 +1,174 @@
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/config"
+)
+
+// DefaultTimeout for notification HTTP requests
+const DefaultTimeout = 10 * time.Second
+
+// Message is the content delivered to a notification sink
+type Message struct {
+	Title   string             `json:"title"`
+	Body    string             `json:"body"`
+	Search  string             `json:"search,omitempty"`
+	Results []api.Availability `json:"results,omitempty"`
+	Time    time.Time          `json:"time"`
+}
+
+// Notifier delivers messages to a single destination
+type Notifier interface {
+	Name() string
+	Notify(msg Message) error
+}
+
+var httpClient = &http.Client{Timeout: DefaultTimeout}
+
+// New creates a notifier from its configuration
+func New(cfg config.NotifierConfig) (Notifier, error) {
+	tmpl, err := parseTemplate(cfg.Name, cfg.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	name := cfg.Name
+	if name == "" {
+		name = cfg.Type
+	}
+
+	switch strings.ToLower(cfg.Type) {
+	case "webhook":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("notifier %q: url is required", name)
+		}
+		return &Webhook{name: name, url: cfg.URL, headers: cfg.Headers, tmpl: tmpl}, nil
+	case "slack", "discord":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("notifier %q: url is required", name)
+		}
+		return &ChatWebhook{name: name, url: cfg.URL, discord: strings.EqualFold(cfg.Type, "discord"), tmpl: tmpl}, nil
+	case "ntfy":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("notifier %q: url is required", name)
+		}
+		return &Ntfy{name: name, url: cfg.URL, priority: cfg.Priority, tags: cfg.Tags, token: cfg.Token, tmpl: tmpl}, nil
+	case "email":
+		if cfg.SMTPHost == "" || cfg.From == "" || len(cfg.To) == 0 {
+			return nil, fmt.Errorf("notifier %q: smtp_host, from and to are required", name)
+		}
+		port := cfg.SMTPPort
+		if port == 0 {
+			port = 587
+		}
+		return &Email{
+			name:     name,
+			addr:     fmt.Sprintf("%s:%d", cfg.SMTPHost, port),
+			host:     cfg.SMTPHost,
+			username: cfg.Username,
+			password: cfg.Password,
+			from:     cfg.From,
+			to:       cfg.To,
+			tmpl:     tmpl,
+		}, nil
+	case "command":
+		if cfg.Command == "" {
+			return nil, fmt.Errorf("notifier %q: command is required", name)
+		}
+		return &Command{name: name, command: cfg.Command, args: cfg.Args, tmpl: tmpl}, nil
+	default:
+		return nil, fmt.Errorf("notifier %q: unknown type %q", name, cfg.Type)
+	}
+}
+
+// FromConfig creates notifiers for every configured sink
+func FromConfig(cfgs []config.NotifierConfig) ([]Notifier, error) {
+	notifiers := make([]Notifier, 0, len(cfgs))
+	for _, c := range cfgs {
+		n, err := New(c)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, n)
+	}
+	return notifiers, nil
+}
+
+// Dispatch sends a message to every notifier, collecting all failures
+func Dispatch(notifiers []Notifier, msg Message) error {
+	if msg.Time.IsZero() {
+		msg.Time = time.Now()
+	}
+
+	var errs []error
+	for _, n := range notifiers {
+		if err := n.Notify(msg); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// parseTemplate compiles a message body template, returning nil if none is set
+func parseTemplate(name, text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New(name).Funcs(template.FuncMap{
+		"cabin":  api.CabinDisplayName,
+		"source": api.SourceDisplayName,
+	}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("notifier %q: invalid template: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// render produces the message body, applying the template if one is configured
+func render(tmpl *template.Template, msg Message) (string, error) {
+	if tmpl == nil {
+		return msg.Body, nil
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, msg); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// maxMessageLines caps how many results are listed in a generated message body
+const maxMessageLines = 10
+
+// ResultsMessage builds a message summarizing availability results for a search
+func ResultsMessage(search string, results []api.Availability) Message {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d results", len(results))
+	for i, a := range results {
+		if i == maxMessageLines {
+			fmt.Fprintf(&b, "\n... and %d more", len(results)-maxMessageLines)
+			break
+		}
+		fmt.Fprintf(&b, "\n%s %s-%s %s", a.Date, a.Route.OriginAirport, a.Route.DestinationAirport, api.SourceDisplayName(a.Source))
+		for _, cabin := range api.ValidCabins() {
+			if c := a.Cabin(cabin); c.Available {
+				fmt.Fprintf(&b, " %s:%s(%d)", cabin, c.MileageCost, c.RemainingSeats)
+			}
+		}
+	}
+
+	return Message{
+		Title:   fmt.Sprintf("seats: %s", search),
+		Body:    b.String(),
+		Search:  search,
+		Results: results,
+		Time:    time.Now(),
+	}
+}
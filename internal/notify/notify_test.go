@@ -0,0 +1,276 @@
+package notify
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/JHill6253/seats-aero-cli/internal/config"
+)
+
+// capture records the last request received by a test HTTP sink
+type capture struct {
+	header http.Header
+	body   string
+}
+
+func httpSink(t *testing.T, status int) (*httptest.Server, *capture) {
+	t.Helper()
+	c := &capture{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		c.header, c.body = r.Header, string(data)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, c
+}
+
+func newNotifier(t *testing.T, cfg config.NotifierConfig) Notifier {
+	t.Helper()
+	n, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return n
+}
+
+func TestWebhook(t *testing.T) {
+	srv, got := httpSink(t, http.StatusOK)
+	n := newNotifier(t, config.NotifierConfig{
+		Type:     "webhook",
+		URL:      srv.URL,
+		Headers:  map[string]string{"X-Token": "secret"},
+		Template: "{{.Search}}!",
+	})
+
+	if err := n.Notify(Message{Title: "t", Body: "b", Search: "sfo"}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if got.header.Get("X-Token") != "secret" {
+		t.Errorf("X-Token = %q", got.header.Get("X-Token"))
+	}
+	var msg Message
+	if err := json.Unmarshal([]byte(got.body), &msg); err != nil {
+		t.Fatalf("body is not JSON: %v", err)
+	}
+	if msg.Body != "sfo!" || msg.Title != "t" {
+		t.Errorf("message = %+v", msg)
+	}
+}
+
+func TestWebhookStatus(t *testing.T) {
+	srv, _ := httpSink(t, http.StatusInternalServerError)
+	n := newNotifier(t, config.NotifierConfig{Type: "webhook", URL: srv.URL})
+
+	if err := n.Notify(Message{Body: "b"}); err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("Notify error = %v, want status 500", err)
+	}
+}
+
+func TestChatWebhook(t *testing.T) {
+	tests := []struct {
+		kind string
+		key  string
+		want string
+	}{
+		{"slack", "text", "*Alert*\nbody"},
+		{"discord", "content", "**Alert**\nbody"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			srv, got := httpSink(t, http.StatusNoContent)
+			n := newNotifier(t, config.NotifierConfig{Type: tt.kind, URL: srv.URL})
+
+			if err := n.Notify(Message{Title: "Alert", Body: "body"}); err != nil {
+				t.Fatalf("Notify: %v", err)
+			}
+			var payload map[string]string
+			if err := json.Unmarshal([]byte(got.body), &payload); err != nil {
+				t.Fatalf("body is not JSON: %v", err)
+			}
+			if payload[tt.key] != tt.want {
+				t.Errorf("%s = %q, want %q", tt.key, payload[tt.key], tt.want)
+			}
+		})
+	}
+}
+
+func TestNtfy(t *testing.T) {
+	srv, got := httpSink(t, http.StatusOK)
+	n := newNotifier(t, config.NotifierConfig{
+		Type:     "ntfy",
+		URL:      srv.URL,
+		Priority: "high",
+		Tags:     []string{"airplane", "money"},
+		Token:    "tk",
+	})
+
+	if err := n.Notify(Message{Title: "Alert", Body: "body"}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if got.body != "body" {
+		t.Errorf("body = %q", got.body)
+	}
+	for key, want := range map[string]string{
+		"Title":         "Alert",
+		"Priority":      "high",
+		"Tags":          "airplane,money",
+		"Authorization": "Bearer tk",
+	} {
+		if v := got.header.Get(key); v != want {
+			t.Errorf("%s = %q, want %q", key, v, want)
+		}
+	}
+}
+
+// smtpSink is a minimal SMTP server that accepts one message
+func smtpSink(t *testing.T) (string, <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	data := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				var b strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					b.WriteString(l)
+				}
+				data <- b.String()
+				reply("250 ok")
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+	return ln.Addr().String(), data
+}
+
+func TestEmail(t *testing.T) {
+	addr, data := smtpSink(t)
+	n := &Email{name: "mail", addr: addr, host: "127.0.0.1", from: "a@example.com", to: []string{"b@example.com"}}
+
+	err := n.Notify(Message{Title: "Alert\r\nBcc: evil@example.com", Body: "line 1\nline 2"})
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	msg := <-data
+	if !strings.Contains(msg, "Subject: Alert Bcc: evil@example.com\r\n") {
+		t.Errorf("subject not folded onto one line:\n%s", msg)
+	}
+	if strings.Contains(msg, "\r\nBcc:") {
+		t.Errorf("header injected:\n%s", msg)
+	}
+	if !strings.Contains(msg, "To: b@example.com\r\n") || !strings.HasSuffix(msg, "line 1\r\nline 2\r\n") {
+		t.Errorf("unexpected message:\n%s", msg)
+	}
+}
+
+func TestHeaderValue(t *testing.T) {
+	tests := map[string]string{
+		"plain":     "plain",
+		"a\r\nb\nc": "a b c",
+		"Zürich 成田": "=?utf-8?q?Z=C3=BCrich_=E6=88=90=E7=94=B0?=",
+	}
+	for in, want := range tests {
+		if got := headerValue(in); got != want {
+			t.Errorf("headerValue(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a POSIX shell")
+	}
+	out := filepath.Join(t.TempDir(), "msg.json")
+	n := newNotifier(t, config.NotifierConfig{
+		Type:     "command",
+		Command:  "sh",
+		Args:     []string{"-c", `cat > "$0"`, out},
+		Template: "{{.Title}} rendered",
+	})
+
+	if err := n.Notify(Message{Title: "Alert", Body: "raw"}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read stdin capture: %v", err)
+	}
+	var msg Message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatalf("stdin is not JSON: %v", err)
+	}
+	if msg.Body != "Alert rendered" {
+		t.Errorf("body = %q, want template output", msg.Body)
+	}
+}
+
+func TestCommandFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a POSIX shell")
+	}
+	n := newNotifier(t, config.NotifierConfig{Type: "command", Command: "sh", Args: []string{"-c", "echo boom >&2; exit 3"}})
+
+	err := n.Notify(Message{Body: "b"})
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Notify error = %v, want stderr in error", err)
+	}
+}
+
+func TestNewValidation(t *testing.T) {
+	bad := []config.NotifierConfig{
+		{Type: "webhook"},
+		{Type: "email", SMTPHost: "smtp"},
+		{Type: "command"},
+		{Type: "pager"},
+		{Type: "slack", URL: "http://x", Template: "{{"},
+	}
+	for _, cfg := range bad {
+		if _, err := New(cfg); err == nil {
+			t.Errorf("New(%+v) succeeded, want error", cfg)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+)
+
+// Ntfy publishes a plain-text push notification to an ntfy-style topic URL
+type Ntfy struct {
+	name     string
+	url      string
+	priority string
+	tags     []string
+	token    string
+	tmpl     *template.Template
+}
+
+// Name returns the notifier name
+func (n *Ntfy) Name() string { return n.name }
+
+// Notify publishes the message to the topic URL
+func (n *Ntfy) Notify(msg Message) error {
+	body, err := render(n.tmpl, msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, n.url, strings.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if msg.Title != "" {
+		req.Header.Set("Title", msg.Title)
+	}
+	if n.priority != "" {
+		req.Header.Set("Priority", n.priority)
+	}
+	if len(n.tags) > 0 {
+		req.Header.Set("Tags", strings.Join(n.tags, ","))
+	}
+	if n.token != "" {
+		req.Header.Set("Authorization", "Bearer "+n.token)
+	}
+
+	return send(req)
+}
@@ -0,0 +1,101 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"text/template"
+)
+
+// Webhook posts the message as JSON to an arbitrary URL
+type Webhook struct {
+	name    string
+	url     string
+	headers map[string]string
+	tmpl    *template.Template
+}
+
+// Name returns the notifier name
+func (w *Webhook) Name() string { return w.name }
+
+// Notify posts the message to the webhook URL
+func (w *Webhook) Notify(msg Message) error {
+	body, err := render(w.tmpl, msg)
+	if err != nil {
+		return err
+	}
+	msg.Body = body
+
+	return postJSON(w.url, w.headers, msg)
+}
+
+// ChatWebhook posts a Slack- or Discord-compatible payload
+type ChatWebhook struct {
+	name    string
+	url     string
+	discord bool
+	tmpl    *template.Template
+}
+
+// Name returns the notifier name
+func (c *ChatWebhook) Name() string { return c.name }
+
+// Notify posts the message to the chat webhook URL
+func (c *ChatWebhook) Notify(msg Message) error {
+	body, err := render(c.tmpl, msg)
+	if err != nil {
+		return err
+	}
+
+	text := body
+	if msg.Title != "" {
+		text = fmt.Sprintf("*%s*\n%s", msg.Title, body)
+	}
+
+	// Slack expects "text", Discord expects "content"
+	payload := map[string]string{"text": text}
+	if c.discord {
+		if msg.Title != "" {
+			text = fmt.Sprintf("**%s**\n%s", msg.Title, body)
+		}
+		payload = map[string]string{"content": text}
+	}
+
+	return postJSON(c.url, nil, payload)
+}
+
+// postJSON sends a JSON payload and checks for a successful response
+func postJSON(url string, headers map[string]string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	return send(req)
+}
+
+// send performs the request and treats any non-2xx status as an error
+func send(req *http.Request) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
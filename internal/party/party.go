@@ -0,0 +1,195 @@
+package party
+
+import (
+	"sort"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// Availability keeps the results where at least one of the cabins (all when
+// empty) has seats for every passenger. Cabins short of seats are marked
+// unavailable so they are not shown or priced.
+func Availability(data []api.Availability, passengers int, cabins []string) []api.Availability {
+	if passengers <= 1 {
+		return data
+	}
+	if len(cabins) == 0 {
+		cabins = api.ValidCabins()
+	}
+
+	result := make([]api.Availability, 0, len(data))
+	for _, a := range data {
+		fits := false
+		for _, code := range api.ValidCabins() {
+			c := a.Cabin(code)
+			if !c.Available {
+				continue
+			}
+			if c.RemainingSeats < passengers {
+				clearCabin(&a, code)
+				continue
+			}
+			if contains(cabins, code) {
+				fits = true
+			}
+		}
+		if fits {
+			result = append(result, a)
+		}
+	}
+	return result
+}
+
+// Trips keeps the trips with seats for every passenger
+func Trips(trips []api.Trip, passengers int) []api.Trip {
+	if passengers <= 1 {
+		return trips
+	}
+
+	result := make([]api.Trip, 0, len(trips))
+	for _, t := range trips {
+		if t.RemainingSeats >= passengers {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// Scale returns copies of the results priced for the party: the API fields
+// are left per passenger and Passengers is set, so Cabin reports the miles
+// and taxes for everyone
+func Scale(data []api.Availability, passengers int) []api.Availability {
+	if passengers <= 1 {
+		return data
+	}
+
+	result := make([]api.Availability, len(data))
+	for i, a := range data {
+		a.Passengers = passengers
+		result[i] = a
+	}
+	return result
+}
+
+// ScaleTrips returns copies of the trips priced for the party, with
+// Passengers set for PartyMiles and PartyTaxes
+func ScaleTrips(trips []api.Trip, passengers int) []api.Trip {
+	if passengers <= 1 {
+		return trips
+	}
+
+	result := make([]api.Trip, len(trips))
+	for i, t := range trips {
+		t.Passengers = passengers
+		result[i] = t
+	}
+	return result
+}
+
+// Part is one booking within a split itinerary
+type Part struct {
+	ID     string `json:"id"`
+	Source string `json:"source"`
+	Cabin  string `json:"cabin"`
+	Seats  int    `json:"seats"` // passengers booked on this part
+	Miles  int    `json:"miles"` // per passenger
+}
+
+// Combo seats a whole party on one date and route across several cabins or
+// programs
+type Combo struct {
+	Date         string `json:"date"`
+	Route        string `json:"route"`
+	Parts        []Part `json:"parts"`
+	TotalMiles   int    `json:"totalMiles"`
+	SplitCabin   bool   `json:"splitCabin"`
+	SplitProgram bool   `json:"splitProgram"`
+}
+
+// Suggest finds split-cabin or split-program combinations that seat every
+// passenger when no single option can. For each date and route it fills the
+// party from the cheapest options first. At most limit combos are returned,
+// cheapest first.
+func Suggest(data []api.Availability, passengers int, cabins []string, limit int) []Combo {
+	if passengers <= 1 {
+		return nil
+	}
+	if len(cabins) == 0 {
+		cabins = api.ValidCabins()
+	}
+
+	type key struct{ date, route string }
+	options := map[key][]Part{}
+	for _, a := range data {
+		k := key{a.Date, a.Route.OriginAirport + "-" + a.Route.DestinationAirport}
+		for _, code := range cabins {
+			c := a.Cabin(code)
+			if !c.Available || c.Miles == 0 || c.RemainingSeats == 0 {
+				continue
+			}
+			options[k] = append(options[k], Part{ID: a.ID, Source: a.Source, Cabin: code, Seats: c.RemainingSeats, Miles: c.Miles})
+		}
+	}
+
+	var combos []Combo
+	for k, parts := range options {
+		sort.Slice(parts, func(i, j int) bool { return parts[i].Miles < parts[j].Miles })
+
+		combo := Combo{Date: k.date, Route: k.route}
+		need := passengers
+		for _, p := range parts {
+			if need == 0 {
+				break
+			}
+			if p.Seats > need {
+				p.Seats = need
+			}
+			need -= p.Seats
+			combo.TotalMiles += p.Miles * p.Seats
+			combo.Parts = append(combo.Parts, p)
+		}
+		// A single part means one option already fits; that isn't a split
+		if need > 0 || len(combo.Parts) < 2 {
+			continue
+		}
+
+		for _, p := range combo.Parts[1:] {
+			combo.SplitCabin = combo.SplitCabin || p.Cabin != combo.Parts[0].Cabin
+			combo.SplitProgram = combo.SplitProgram || p.Source != combo.Parts[0].Source
+		}
+		combos = append(combos, combo)
+	}
+
+	sort.Slice(combos, func(i, j int) bool {
+		if combos[i].TotalMiles != combos[j].TotalMiles {
+			return combos[i].TotalMiles < combos[j].TotalMiles
+		}
+		return combos[i].Date < combos[j].Date
+	})
+	if limit > 0 && len(combos) > limit {
+		combos = combos[:limit]
+	}
+	return combos
+}
+
+func clearCabin(a *api.Availability, code string) {
+	switch code {
+	case "Y":
+		a.YAvailable = false
+	case "W":
+		a.WAvailable = false
+	case "J":
+		a.JAvailable = false
+	case "F":
+		a.FAvailable = false
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
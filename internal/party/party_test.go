@@ -0,0 +1,85 @@
+package party
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+func TestScaleKeepsAPIFields(t *testing.T) {
+	data := []api.Availability{{ID: "a", JAvailable: true, JMileageCost: "70000", JTotalTaxes: 5600, JDirectMileageCost: 80000, JRemainingSeats: 4}}
+
+	scaled := Scale(data, 2)
+	if data[0].Passengers != 0 {
+		t.Error("Scale modified its input")
+	}
+
+	a := scaled[0]
+	if a.JMileageCost != "70000" || a.JTotalTaxes != 5600 || a.Passengers != 2 {
+		t.Errorf("API fields changed: %+v", a)
+	}
+	c := a.Cabin("J")
+	if c.Miles != 140000 || c.MileageCost != "140000" || c.TotalTaxes != 11200 || c.DirectMiles != 160000 || c.RemainingSeats != 4 {
+		t.Errorf("Cabin = %+v, want party totals", c)
+	}
+
+	out, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), `"JMileageCost":"70000"`) || !strings.Contains(string(out), `"Passengers":2`) {
+		t.Errorf("JSON = %s", out)
+	}
+}
+
+func TestScaleTrips(t *testing.T) {
+	trips := ScaleTrips([]api.Trip{{MileageCost: 70000, TotalTaxes: 5600}}, 3)
+	tr := trips[0]
+	if tr.MileageCost != 70000 || tr.TotalTaxes != 5600 {
+		t.Errorf("API fields changed: %+v", tr)
+	}
+	if tr.PartyMiles() != 210000 || tr.PartyTaxes() != 16800 {
+		t.Errorf("party totals = %d, %d", tr.PartyMiles(), tr.PartyTaxes())
+	}
+
+	if single := (api.Trip{MileageCost: 70000}); single.PartyMiles() != 70000 {
+		t.Errorf("PartyMiles without Passengers = %d", single.PartyMiles())
+	}
+}
+
+func TestAvailability(t *testing.T) {
+	data := []api.Availability{
+		{ID: "fits", JAvailable: true, JMileageCost: "70000", JRemainingSeats: 2, YAvailable: true, YMileageCost: "30000", YRemainingSeats: 1},
+		{ID: "short", JAvailable: true, JMileageCost: "60000", JRemainingSeats: 1},
+	}
+
+	got := Availability(data, 2, nil)
+	if len(got) != 1 || got[0].ID != "fits" {
+		t.Fatalf("Availability = %+v", got)
+	}
+	if got[0].YAvailable {
+		t.Error("cabin short of seats still available")
+	}
+	if empty := Availability(data, 5, nil); empty == nil || len(empty) != 0 {
+		t.Errorf("Availability with nothing fitting = %#v, want an empty slice", empty)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	data := []api.Availability{
+		{ID: "a", Date: "2024-06-01", Source: "aeroplan", JAvailable: true, JMileageCost: "70000", JRemainingSeats: 2},
+		{ID: "b", Date: "2024-06-01", Source: "united", JAvailable: true, JMileageCost: "80000", JRemainingSeats: 1},
+		{ID: "c", Date: "2024-06-02", Source: "united", JAvailable: true, JMileageCost: "80000", JRemainingSeats: 1},
+	}
+
+	combos := Suggest(data, 3, []string{"J"}, 0)
+	if len(combos) != 1 {
+		t.Fatalf("Suggest = %+v, want one combo", combos)
+	}
+	c := combos[0]
+	if c.TotalMiles != 220000 || !c.SplitProgram || c.SplitCabin || len(c.Parts) != 2 {
+		t.Errorf("combo = %+v", c)
+	}
+}
@@ -0,0 +1,199 @@
+package saved
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/filter"
+)
+
+// Search is a named, reusable search definition
+type Search struct {
+	Name       string         `yaml:"name"`
+	From       []string       `yaml:"from"`
+	To         []string       `yaml:"to"`
+	StartDate  string         `yaml:"start_date,omitempty"` // YYYY-MM-DD, today, +14d, +2w, +3m
+	EndDate    string         `yaml:"end_date,omitempty"`
+	Cabin      string         `yaml:"cabin,omitempty"`
+	Sources    []string       `yaml:"sources,omitempty"`
+	DirectOnly bool           `yaml:"direct_only,omitempty"`
+	Filters    filter.Options `yaml:"filters,omitempty"`
+	Output     string         `yaml:"output,omitempty"`
+	Notify     []string       `yaml:"notify,omitempty"`
+	Schedule   string         `yaml:"schedule,omitempty"` // cron expression or @every <duration>, used by the daemon
+}
+
+// Params converts the saved search into API search parameters, resolving
+// relative dates against now
+func (s Search) Params(now time.Time) (api.SearchParams, error) {
+	start, err := ResolveDate(s.StartDate, now)
+	if err != nil {
+		return api.SearchParams{}, fmt.Errorf("start date: %w", err)
+	}
+	end, err := ResolveDate(s.EndDate, now)
+	if err != nil {
+		return api.SearchParams{}, fmt.Errorf("end date: %w", err)
+	}
+
+	return api.SearchParams{
+		OriginAirports:      s.From,
+		DestinationAirports: s.To,
+		StartDate:           start,
+		EndDate:             end,
+		Cabin:               s.Cabin,
+		Sources:             s.Sources,
+		DirectOnly:          s.DirectOnly,
+	}, nil
+}
+
+// Describe returns a short human-readable summary of the route
+func (s Search) Describe() string {
+	return fmt.Sprintf("%s → %s", strings.Join(s.From, ","), strings.Join(s.To, ","))
+}
+
+// Store holds saved searches backed by a YAML file
+type Store struct {
+	path     string
+	Searches []Search `yaml:"searches"`
+}
+
+// DefaultPath returns the location of the saved searches file, next to config.yaml
+func DefaultPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "seats-aero", "saved.yaml"), nil
+}
+
+// Load reads saved searches from path, returning an empty store if it doesn't exist
+func Load(path string) (*Store, error) {
+	store := &Store{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("failed to read saved searches: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("failed to parse saved searches: %w", err)
+	}
+
+	return store, nil
+}
+
+// Save writes the store back to its file
+func (s *Store) Save() error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode saved searches: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write saved searches: %w", err)
+	}
+	return nil
+}
+
+// Path returns the file backing the store
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Get returns the saved search with the given name
+func (s *Store) Get(name string) (Search, bool) {
+	for _, search := range s.Searches {
+		if search.Name == name {
+			return search, true
+		}
+	}
+	return Search{}, false
+}
+
+// Put adds a saved search, replacing an existing one only if replace is set
+func (s *Store) Put(search Search, replace bool) error {
+	if strings.TrimSpace(search.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	for i, existing := range s.Searches {
+		if existing.Name == search.Name {
+			if !replace {
+				return fmt.Errorf("saved search %q already exists", search.Name)
+			}
+			s.Searches[i] = search
+			return nil
+		}
+	}
+
+	s.Searches = append(s.Searches, search)
+	return nil
+}
+
+// Remove deletes the saved search with the given name
+func (s *Store) Remove(name string) error {
+	for i, search := range s.Searches {
+		if search.Name == name {
+			s.Searches = append(s.Searches[:i], s.Searches[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("saved search %q not found", name)
+}
+
+var relativeDate = regexp.MustCompile(`^\+(\d+)([dwm])$`)
+
+// ResolveDate converts a saved date expression to YYYY-MM-DD. Absolute dates
+// pass through unchanged; "today" and offsets like +14d, +2w or +3m are
+// resolved relative to now so saved searches don't go stale. Month offsets
+// stop at the end of a shorter month, so Jan 31 +1m is the end of February.
+func ResolveDate(expr string, now time.Time) (string, error) {
+	expr = strings.ToLower(strings.TrimSpace(expr))
+	if expr == "" {
+		return "", nil
+	}
+	if expr == "today" {
+		return now.Format("2006-01-02"), nil
+	}
+
+	if m := relativeDate.FindStringSubmatch(expr); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch m[2] {
+		case "d":
+			return now.AddDate(0, 0, n).Format("2006-01-02"), nil
+		case "w":
+			return now.AddDate(0, 0, 7*n).Format("2006-01-02"), nil
+		case "m":
+			return addMonths(now, n).Format("2006-01-02"), nil
+		}
+	}
+
+	if _, err := time.Parse("2006-01-02", expr); err != nil {
+		return "", fmt.Errorf("invalid date %q (use YYYY-MM-DD, today or +N[d|w|m])", expr)
+	}
+	return expr, nil
+}
+
+// addMonths adds n months, clamping the day to the end of the target month
+// rather than rolling over into the next one as time.AddDate does
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return time.Date(first.Year(), first.Month(), min(t.Day(), last), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
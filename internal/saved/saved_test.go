@@ -0,0 +1,104 @@
+package saved
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestResolveDate(t *testing.T) {
+	now := time.Date(2026, time.January, 31, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"", ""},
+		{"today", "2026-01-31"},
+		{" Today ", "2026-01-31"},
+		{"+0d", "2026-01-31"},
+		{"+1d", "2026-02-01"},
+		{"+14d", "2026-02-14"},
+		{"+2w", "2026-02-14"},
+		{"+1m", "2026-02-28"}, // clamped to the end of February
+		{"+3m", "2026-04-30"},
+		{"+12m", "2027-01-31"},
+		{"+13m", "2027-02-28"},
+		{"2026-06-01", "2026-06-01"},
+	}
+	for _, tt := range tests {
+		got, err := ResolveDate(tt.expr, now)
+		if err != nil {
+			t.Errorf("ResolveDate(%q): %v", tt.expr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ResolveDate(%q) = %q, want %q", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestResolveDateLeapYear(t *testing.T) {
+	now := time.Date(2027, time.December, 31, 0, 0, 0, 0, time.UTC)
+	if got, _ := ResolveDate("+2m", now); got != "2028-02-29" {
+		t.Errorf("ResolveDate(+2m) = %q, want 2028-02-29", got)
+	}
+}
+
+func TestResolveDateInvalid(t *testing.T) {
+	now := time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC)
+	for _, expr := range []string{"tomorrow", "+d", "-3d", "+3y", "+1.5w", "2026-13-01", "2026/06/01", "14d"} {
+		if got, err := ResolveDate(expr, now); err == nil {
+			t.Errorf("ResolveDate(%q) = %q, want error", expr, got)
+		}
+	}
+}
+
+func TestPut(t *testing.T) {
+	store := &Store{}
+
+	for _, name := range []string{"", "   "} {
+		if err := store.Put(Search{Name: name}, false); err == nil {
+			t.Errorf("Put(%q) succeeded, want error", name)
+		}
+	}
+
+	if err := store.Put(Search{Name: "tokyo", To: []string{"NRT"}}, false); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := store.Put(Search{Name: "tokyo", To: []string{"HND"}}, false); err == nil {
+		t.Error("Put of an existing name succeeded without replace")
+	}
+	if err := store.Put(Search{Name: "tokyo", To: []string{"HND"}}, true); err != nil {
+		t.Fatalf("Put with replace: %v", err)
+	}
+	if got, _ := store.Get("tokyo"); len(store.Searches) != 1 || got.To[0] != "HND" {
+		t.Errorf("searches = %+v, want the replaced search only", store.Searches)
+	}
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "saved.yaml")
+	store, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load of a missing file: %v", err)
+	}
+	if err := store.Put(Search{Name: "tokyo", From: []string{"SFO"}, To: []string{"NRT"}, StartDate: "+2w"}, false); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	got, ok := loaded.Get("tokyo")
+	if !ok || got.StartDate != "+2w" || got.From[0] != "SFO" {
+		t.Errorf("loaded %+v", loaded.Searches)
+	}
+	if err := loaded.Remove("tokyo"); err != nil || loaded.Remove("tokyo") == nil {
+		t.Errorf("Remove: want success then not found, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func at(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		from string
+		want string
+	}{
+		{"every minute", "* * * * *", "2024-06-01 10:15", "2024-06-01 10:16"},
+		{"fixed time later today", "30 14 * * *", "2024-06-01 10:15", "2024-06-01 14:30"},
+		{"fixed time tomorrow", "30 9 * * *", "2024-06-01 10:15", "2024-06-02 09:30"},
+		{"minute step", "*/15 * * * *", "2024-06-01 10:15", "2024-06-01 10:30"},
+		{"hour step", "0 */6 * * *", "2024-06-01 10:15", "2024-06-01 12:00"},
+		{"step from offset", "5/20 * * * *", "2024-06-01 10:26", "2024-06-01 10:45"},
+		{"range", "0 9-17 * * *", "2024-06-01 17:30", "2024-06-02 09:00"},
+		{"range with step", "0 8-18/4 * * *", "2024-06-01 12:01", "2024-06-01 16:00"},
+		{"list", "0 6,18 * * *", "2024-06-01 07:00", "2024-06-01 18:00"},
+		{"day of week", "0 0 * * 1", "2024-06-01 10:00", "2024-06-03 00:00"}, // Saturday to Monday
+		{"sunday as 7", "0 0 * * 7", "2024-06-01 10:00", "2024-06-02 00:00"},
+		{"weekday range", "0 9 * * 1-5", "2024-06-07 10:00", "2024-06-10 09:00"}, // Friday to Monday
+		{"day of month", "0 0 15 * *", "2024-06-01 10:00", "2024-06-15 00:00"},
+		{"dom or dow", "0 0 15 * 1", "2024-06-01 10:00", "2024-06-03 00:00"}, // Monday comes first
+		{"dom or dow, dom first", "0 0 2 * 5", "2024-06-01 10:00", "2024-06-02 00:00"},
+		{"month rollover", "0 0 1 * *", "2024-06-15 10:00", "2024-07-01 00:00"},
+		{"year rollover", "0 0 1 1 *", "2024-06-15 10:00", "2025-01-01 00:00"},
+		{"skips short months", "0 0 31 * *", "2024-04-01 00:00", "2024-05-31 00:00"},
+		{"leap day", "0 0 29 2 *", "2025-01-01 00:00", "2028-02-29 00:00"},
+		{"end of day rollover", "59 23 * * *", "2024-12-31 23:59", "2025-01-01 23:59"},
+		{"hourly macro", "@hourly", "2024-06-01 10:15", "2024-06-01 11:00"},
+		{"daily macro", "@daily", "2024-06-01 10:15", "2024-06-02 00:00"},
+		{"weekly macro", "@weekly", "2024-06-01 10:15", "2024-06-02 00:00"},
+		{"monthly macro", "@monthly", "2024-06-01 10:15", "2024-07-01 00:00"},
+		{"interval", "@every 90m", "2024-06-01 10:15", "2024-06-01 11:45"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.expr, err)
+			}
+			if got := s.Next(at(tt.from)); !got.Equal(at(tt.want)) {
+				t.Errorf("Next(%s) = %s, want %s", tt.from, got.Format("2006-01-02 15:04 Mon"), tt.want)
+			}
+		})
+	}
+}
+
+func TestNextNever(t *testing.T) {
+	s, err := Parse("0 0 31 2 *")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got := s.Next(at("2024-01-01 00:00")); !got.IsZero() {
+		t.Errorf("Next = %s, want zero for February 31", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@every 30s",
+		"@every soon",
+	} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", expr)
+		}
+	}
+}
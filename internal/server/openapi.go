@@ -0,0 +1,8 @@
+package server
+
+import _ "embed"
+
+// openAPISpec documents the server's endpoints
+//
+//go:embed openapi.json
+var openAPISpec []byte
@@ -0,0 +1,297 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+	"github.com/JHill6253/seats-aero-cli/internal/filter"
+)
+
+// Options configures a Server
+type Options struct {
+	Client   *api.Client
+	Quota    *api.Quota
+	Token    string        // bearer token required from clients, empty to disable auth
+	CacheTTL time.Duration // 0 disables caching
+	Logger   *log.Logger
+}
+
+// Server exposes the seats.aero client as a local JSON API
+type Server struct {
+	opts  Options
+	cache *cache
+}
+
+// New creates a server
+func New(opts Options) *Server {
+	if opts.Logger == nil {
+		opts.Logger = log.Default()
+	}
+	return &Server{opts: opts, cache: newCache(opts.CacheTTL)}
+}
+
+// listResponse wraps result lists in the same shape as the upstream API
+type listResponse struct {
+	Data  interface{} `json:"data"`
+	Count int         `json:"count"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handler returns the HTTP handler for all server routes
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("GET /openapi.json", s.handleOpenAPI)
+	mux.Handle("GET /v1/search", s.auth(http.HandlerFunc(s.handleSearch)))
+	mux.Handle("GET /v1/availability", s.auth(http.HandlerFunc(s.handleAvailability)))
+	mux.Handle("GET /v1/routes", s.auth(http.HandlerFunc(s.handleRoutes)))
+	mux.Handle("GET /v1/trips/{id}", s.auth(http.HandlerFunc(s.handleTrips)))
+	return s.logRequests(mux)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	status := map[string]interface{}{"status": "ok"}
+	if s.opts.Quota != nil {
+		status["quota"] = map[string]int{"used": s.opts.Quota.Used(), "limit": s.opts.Quota.Limit()}
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPISpec)
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	params := api.SearchParams{
+		OriginAirports:      splitList(q.Get("from"), strings.ToUpper),
+		DestinationAirports: splitList(q.Get("to"), strings.ToUpper),
+		StartDate:           q.Get("start_date"),
+		EndDate:             q.Get("end_date"),
+		Cabin:               api.CabinParam(q.Get("cabin")),
+		Sources:             splitList(q.Get("source"), strings.ToLower),
+		DirectOnly:          q.Get("direct") == "true",
+	}
+	if len(params.OriginAirports) == 0 || len(params.DestinationAirports) == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("from and to are required"))
+		return
+	}
+
+	opts, err := filterOptions(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	data, err := s.cached("search", params, func() (interface{}, error) {
+		resp, err := s.opts.Client.Search(params)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Data, nil
+	})
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+
+	results := filter.Availability(data.([]api.Availability), opts)
+	writeJSON(w, http.StatusOK, listResponse{Data: results, Count: len(results)})
+}
+
+func (s *Server) handleAvailability(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	params := api.AvailabilityParams{
+		Source:       strings.ToLower(q.Get("source")),
+		Cabin:        api.CabinParam(q.Get("cabin")),
+		OriginRegion: q.Get("origin_region"),
+		DestRegion:   q.Get("dest_region"),
+		StartDate:    q.Get("start_date"),
+		EndDate:      q.Get("end_date"),
+	}
+	if params.Source == "" {
+		writeError(w, http.StatusBadRequest, errors.New("source is required"))
+		return
+	}
+
+	opts, err := filterOptions(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	data, err := s.cached("availability", params, func() (interface{}, error) {
+		resp, err := s.opts.Client.GetAvailability(params)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Data, nil
+	})
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+
+	results := filter.Availability(data.([]api.Availability), opts)
+	writeJSON(w, http.StatusOK, listResponse{Data: results, Count: len(results)})
+}
+
+func (s *Server) handleRoutes(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	params := api.RoutesParams{
+		Source: strings.ToLower(q.Get("source")),
+		Origin: strings.ToUpper(q.Get("origin")),
+	}
+
+	data, err := s.cached("routes", params, func() (interface{}, error) {
+		resp, err := s.opts.Client.GetRoutes(params)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Data, nil
+	})
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+
+	routes := data.([]api.Route)
+	writeJSON(w, http.StatusOK, listResponse{Data: routes, Count: len(routes)})
+}
+
+func (s *Server) handleTrips(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	data, err := s.cached("trips", id, func() (interface{}, error) {
+		resp, err := s.opts.Client.GetTrips(id)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Data, nil
+	})
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+
+	trips := data.([]api.Trip)
+	writeJSON(w, http.StatusOK, listResponse{Data: trips, Count: len(trips)})
+}
+
+// cached returns a cached upstream result for the endpoint and parameters, or
+// fetches and caches it
+func (s *Server) cached(endpoint string, params interface{}, fetch func() (interface{}, error)) (interface{}, error) {
+	key := fmt.Sprintf("%s:%+v", endpoint, params)
+	if v, ok := s.cache.get(key); ok {
+		return v, nil
+	}
+
+	v, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	s.cache.set(key, v)
+	return v, nil
+}
+
+// auth requires a matching bearer token when one is configured
+func (s *Server) auth(next http.Handler) http.Handler {
+	if s.opts.Token == "" {
+		return next
+	}
+	expected := []byte("Bearer " + s.opts.Token)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, expected) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, errors.New("missing or invalid bearer token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// statusRecorder captures the response status for request logging
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// logRequests logs the method, path, status and duration of every request
+func (s *Server) logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		s.opts.Logger.Printf("%s %s %d %s", r.Method, r.URL.RequestURI(), rec.status, time.Since(start).Round(time.Millisecond))
+	})
+}
+
+// filterOptions reads the post-fetch filters shared by search and availability
+func filterOptions(q url.Values) (filter.Options, error) {
+	opts := filter.Options{
+		Cabins:     splitList(q.Get("cabins"), strings.ToUpper),
+		DirectOnly: q.Get("direct") == "true",
+	}
+
+	var err error
+	if v := q.Get("max_miles"); v != "" {
+		if opts.MaxMiles, err = strconv.Atoi(v); err != nil {
+			return opts, fmt.Errorf("invalid max_miles %q", v)
+		}
+	}
+	if v := q.Get("min_seats"); v != "" {
+		if opts.MinSeats, err = strconv.Atoi(v); err != nil {
+			return opts, fmt.Errorf("invalid min_seats %q", v)
+		}
+	}
+	return opts, nil
+}
+
+func splitList(s string, normalize func(string) string) []string {
+	var result []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			result = append(result, normalize(p))
+		}
+	}
+	return result
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// writeUpstreamError maps client failures to an HTTP status
+func writeUpstreamError(w http.ResponseWriter, err error) {
+	if errors.Is(err, api.ErrQuotaExceeded) {
+		writeError(w, http.StatusTooManyRequests, err)
+		return
+	}
+	writeError(w, http.StatusBadGateway, err)
+}
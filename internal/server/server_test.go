@@ -0,0 +1,301 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// upstream is a fake seats.aero API that counts requests per path
+type upstream struct {
+	mu     sync.Mutex
+	hits   map[string]int
+	status int
+	query  map[string]string
+}
+
+func (u *upstream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u.mu.Lock()
+	u.hits[r.URL.Path]++
+	u.query[r.URL.Path] = r.URL.RawQuery
+	status := u.status
+	u.mu.Unlock()
+
+	if r.Header.Get("Partner-Authorization") != "key" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if status != 0 {
+		w.WriteHeader(status)
+		io.WriteString(w, "upstream failure")
+		return
+	}
+
+	switch r.URL.Path {
+	case "/search", "/availability":
+		io.WriteString(w, `{"data":[
+			{"ID":"a1","Route":{"OriginAirport":"SFO","DestinationAirport":"NRT"},"Date":"2024-06-01","Source":"aeroplan","JAvailable":true,"JMileageCost":"75000","JRemainingSeats":2},
+			{"ID":"a2","Route":{"OriginAirport":"SFO","DestinationAirport":"NRT"},"Date":"2024-06-02","Source":"aeroplan","JAvailable":true,"JMileageCost":"110000","JRemainingSeats":1}
+		],"count":2,"hasMore":false}`)
+	case "/routes":
+		io.WriteString(w, `{"data":[{"ID":"r1","OriginAirport":"SFO","DestinationAirport":"NRT","Distance":5130,"Source":"aeroplan"}],"count":1}`)
+	case "/trips/a1":
+		io.WriteString(w, `{"data":[{"ID":"t1","AvailabilityID":"a1","FlightNumbers":"NH7","MileageCost":75000,"Cabin":"business"}],"count":1}`)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (u *upstream) count(path string) int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.hits[path]
+}
+
+func newTestServer(t *testing.T, opts Options) (*httptest.Server, *upstream) {
+	t.Helper()
+	up := &upstream{hits: map[string]int{}, query: map[string]string{}}
+	fake := httptest.NewServer(up)
+	t.Cleanup(fake.Close)
+
+	if opts.Client == nil {
+		opts.Client = api.NewClient("key").WithBaseURL(fake.URL)
+	}
+	if opts.Quota != nil {
+		opts.Client.WithQuota(opts.Quota)
+	}
+	opts.Logger = log.New(io.Discard, "", 0)
+
+	srv := httptest.NewServer(New(opts).Handler())
+	t.Cleanup(srv.Close)
+	return srv, up
+}
+
+// getJSON requests a path and decodes the response body into v
+func getJSON(t *testing.T, url, token string, v interface{}) int {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("%s: Content-Type = %q", url, ct)
+	}
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("%s: decode: %v", url, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+type availabilityList struct {
+	Data  []api.Availability `json:"data"`
+	Count int                `json:"count"`
+}
+
+func TestEndpoints(t *testing.T) {
+	srv, up := newTestServer(t, Options{})
+
+	var search availabilityList
+	if status := getJSON(t, srv.URL+"/v1/search?from=sfo&to=nrt&cabin=J", "", &search); status != http.StatusOK {
+		t.Fatalf("search status = %d", status)
+	}
+	if search.Count != 2 || len(search.Data) != 2 {
+		t.Errorf("search = %+v", search)
+	}
+	if q := up.query["/search"]; q == "" {
+		t.Error("search sent no query to upstream")
+	}
+
+	var filtered availabilityList
+	getJSON(t, srv.URL+"/v1/search?from=SFO&to=NRT&max_miles=80000", "", &filtered)
+	if filtered.Count != 1 || filtered.Data[0].ID != "a1" {
+		t.Errorf("max_miles filter = %+v", filtered)
+	}
+
+	var avail availabilityList
+	if status := getJSON(t, srv.URL+"/v1/availability?source=aeroplan&min_seats=2", "", &avail); status != http.StatusOK {
+		t.Fatalf("availability status = %d", status)
+	}
+	if avail.Count != 1 || avail.Data[0].ID != "a1" {
+		t.Errorf("availability = %+v", avail)
+	}
+
+	var routes struct {
+		Data  []api.Route `json:"data"`
+		Count int         `json:"count"`
+	}
+	if status := getJSON(t, srv.URL+"/v1/routes?source=aeroplan", "", &routes); status != http.StatusOK {
+		t.Fatalf("routes status = %d", status)
+	}
+	if routes.Count != 1 || routes.Data[0].DestinationAirport != "NRT" {
+		t.Errorf("routes = %+v", routes)
+	}
+
+	var trips struct {
+		Data  []api.Trip `json:"data"`
+		Count int        `json:"count"`
+	}
+	if status := getJSON(t, srv.URL+"/v1/trips/a1", "", &trips); status != http.StatusOK {
+		t.Fatalf("trips status = %d", status)
+	}
+	if trips.Count != 1 || trips.Data[0].FlightNumbers != "NH7" {
+		t.Errorf("trips = %+v", trips)
+	}
+}
+
+func TestBadRequests(t *testing.T) {
+	srv, up := newTestServer(t, Options{})
+
+	for _, path := range []string{
+		"/v1/search?from=SFO",
+		"/v1/search?from=SFO&to=NRT&max_miles=lots",
+		"/v1/availability",
+		"/v1/availability?source=aeroplan&min_seats=two",
+	} {
+		var body errorResponse
+		if status := getJSON(t, srv.URL+path, "", &body); status != http.StatusBadRequest || body.Error == "" {
+			t.Errorf("%s: status %d, error %q", path, status, body.Error)
+		}
+	}
+	if n := up.count("/search") + up.count("/availability"); n != 0 {
+		t.Errorf("bad requests reached upstream %d times", n)
+	}
+}
+
+func TestCache(t *testing.T) {
+	srv, up := newTestServer(t, Options{CacheTTL: 50 * time.Millisecond})
+
+	for i := 0; i < 3; i++ {
+		getJSON(t, srv.URL+"/v1/routes?source=aeroplan", "", nil)
+	}
+	if n := up.count("/routes"); n != 1 {
+		t.Errorf("upstream hits within TTL = %d, want 1", n)
+	}
+
+	// Different parameters are cached separately
+	getJSON(t, srv.URL+"/v1/routes?source=united", "", nil)
+	if n := up.count("/routes"); n != 2 {
+		t.Errorf("upstream hits for new params = %d, want 2", n)
+	}
+
+	time.Sleep(80 * time.Millisecond)
+	getJSON(t, srv.URL+"/v1/routes?source=aeroplan", "", nil)
+	if n := up.count("/routes"); n != 3 {
+		t.Errorf("upstream hits after expiry = %d, want 3", n)
+	}
+}
+
+func TestCacheDisabled(t *testing.T) {
+	srv, up := newTestServer(t, Options{})
+
+	getJSON(t, srv.URL+"/v1/trips/a1", "", nil)
+	getJSON(t, srv.URL+"/v1/trips/a1", "", nil)
+	if n := up.count("/trips/a1"); n != 2 {
+		t.Errorf("upstream hits without cache = %d, want 2", n)
+	}
+}
+
+func TestUpstreamErrors(t *testing.T) {
+	srv, up := newTestServer(t, Options{})
+	up.status = http.StatusInternalServerError
+
+	var body errorResponse
+	if status := getJSON(t, srv.URL+"/v1/routes", "", &body); status != http.StatusBadGateway {
+		t.Errorf("upstream failure status = %d, want 502", status)
+	}
+	if body.Error == "" {
+		t.Error("upstream failure has no error message")
+	}
+}
+
+func TestQuotaExceeded(t *testing.T) {
+	srv, up := newTestServer(t, Options{Quota: api.NewQuota(1)})
+
+	if status := getJSON(t, srv.URL+"/v1/routes", "", nil); status != http.StatusOK {
+		t.Fatalf("first request status = %d", status)
+	}
+	if status := getJSON(t, srv.URL+"/v1/routes?source=united", "", nil); status != http.StatusTooManyRequests {
+		t.Errorf("over quota status = %d, want 429", status)
+	}
+	if n := up.count("/routes"); n != 1 {
+		t.Errorf("upstream hits = %d, want the over-quota request blocked", n)
+	}
+
+	var health struct {
+		Quota struct{ Used, Limit int } `json:"quota"`
+	}
+	getJSON(t, srv.URL+"/healthz", "", &health)
+	if health.Quota.Used != 1 || health.Quota.Limit != 1 {
+		t.Errorf("health quota = %+v", health.Quota)
+	}
+}
+
+func TestAuth(t *testing.T) {
+	srv, _ := newTestServer(t, Options{Token: "secret"})
+
+	if status := getJSON(t, srv.URL+"/v1/routes", "", nil); status != http.StatusUnauthorized {
+		t.Errorf("no token status = %d, want 401", status)
+	}
+	if status := getJSON(t, srv.URL+"/v1/routes", "wrong", nil); status != http.StatusUnauthorized {
+		t.Errorf("wrong token status = %d, want 401", status)
+	}
+	if status := getJSON(t, srv.URL+"/v1/routes", "secret", nil); status != http.StatusOK {
+		t.Errorf("valid token status = %d, want 200", status)
+	}
+	// Health and the spec stay open
+	if status := getJSON(t, srv.URL+"/healthz", "", nil); status != http.StatusOK {
+		t.Errorf("healthz status = %d, want 200", status)
+	}
+	if status := getJSON(t, srv.URL+"/openapi.json", "", nil); status != http.StatusOK {
+		t.Errorf("openapi status = %d, want 200", status)
+	}
+}
+
+func TestOpenAPIParameters(t *testing.T) {
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				Name string `json:"name"`
+			} `json:"parameters"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(openAPISpec, &spec); err != nil {
+		t.Fatalf("invalid spec: %v", err)
+	}
+
+	// Every query parameter the handlers read must be documented
+	want := map[string][]string{
+		"/v1/search":       {"from", "to", "start_date", "end_date", "cabin", "source", "direct", "cabins", "max_miles", "min_seats"},
+		"/v1/availability": {"source", "cabin", "origin_region", "dest_region", "start_date", "end_date", "direct", "cabins", "max_miles", "min_seats"},
+		"/v1/routes":       {"source", "origin"},
+	}
+	for path, names := range want {
+		documented := map[string]bool{}
+		for _, p := range spec.Paths[path]["get"].Parameters {
+			documented[p.Name] = true
+		}
+		for _, name := range names {
+			if !documented[name] {
+				t.Errorf("%s does not document %q", path, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,412 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// TripsFetcher loads trip details for an availability ID
+type TripsFetcher func(availabilityID string) ([]api.Trip, error)
+
+var (
+	headerStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
+	helpStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+	errorStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+	detailStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#6B7280")).
+			Padding(0, 1)
+)
+
+// sortKeys are cycled with the "s" key
+var sortKeys = []string{"date", "origin", "destination", "source", "Y", "W", "J", "F"}
+
+// detailHeight is the number of content lines in the detail pane
+const detailHeight = 10
+
+// tripsMsg carries the result of fetching trips for a row
+type tripsMsg struct {
+	id    string
+	trips []api.Trip
+	err   error
+}
+
+// Model is the Bubble Tea model for the results browser
+type Model struct {
+	results    []api.Availability
+	view       []int // indexes into results after filtering and sorting
+	fetchTrips TripsFetcher
+
+	table     table.Model
+	filter    textinput.Model
+	filtering bool
+
+	cabins  map[string]bool
+	sortKey int
+	reverse bool
+
+	trips    map[string][]api.Trip
+	tripErrs map[string]error
+	loading  string
+
+	width, height int
+}
+
+// New creates a results browser model. fetchTrips may be nil to disable
+// trip lookups.
+func New(results []api.Availability, fetchTrips TripsFetcher) Model {
+	filter := textinput.New()
+	filter.Prompt = "/"
+	filter.Placeholder = "filter by date, airport, program or airline"
+
+	t := table.New(table.WithFocused(true))
+	styles := table.DefaultStyles()
+	styles.Header = styles.Header.Bold(true).BorderStyle(lipgloss.NormalBorder()).BorderBottom(true)
+	styles.Selected = styles.Selected.Foreground(lipgloss.Color("#FFFFFF")).Background(lipgloss.Color("#7C3AED"))
+	t.SetStyles(styles)
+
+	m := Model{
+		results:    results,
+		fetchTrips: fetchTrips,
+		table:      t,
+		filter:     filter,
+		cabins:     map[string]bool{"Y": true, "W": true, "J": true, "F": true},
+		trips:      map[string][]api.Trip{},
+		tripErrs:   map[string]error{},
+		width:      100,
+		height:     30,
+	}
+	m.refresh()
+	return m
+}
+
+// Browse runs the results browser full-screen until the user quits
+func Browse(results []api.Availability, fetchTrips TripsFetcher) error {
+	_, err := tea.NewProgram(New(results, fetchTrips), tea.WithAltScreen()).Run()
+	return err
+}
+
+// Init implements tea.Model
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.resize()
+		return m, nil
+
+	case tripsMsg:
+		if m.loading == msg.id {
+			m.loading = ""
+		}
+		if msg.err != nil {
+			m.tripErrs[msg.id] = msg.err
+		} else {
+			m.trips[msg.id] = msg.trips
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.filtering {
+			return m.updateFilter(msg)
+		}
+
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return m, tea.Quit
+		case "/":
+			m.filtering = true
+			m.filter.Focus()
+			return m, textinput.Blink
+		case "s":
+			m.sortKey = (m.sortKey + 1) % len(sortKeys)
+			m.refresh()
+			return m, nil
+		case "r":
+			m.reverse = !m.reverse
+			m.refresh()
+			return m, nil
+		case "1", "2", "3", "4":
+			cabin := api.ValidCabins()[msg.String()[0]-'1']
+			m.cabins[cabin] = !m.cabins[cabin]
+			m.refresh()
+			return m, nil
+		case "t", "enter":
+			return m, m.loadTrips()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+func (m Model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.filtering = false
+		m.filter.Blur()
+		return m, nil
+	case "esc":
+		m.filtering = false
+		m.filter.Blur()
+		m.filter.SetValue("")
+		m.refresh()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(msg)
+	m.refresh()
+	return m, cmd
+}
+
+// loadTrips fetches trips for the highlighted row unless already loaded
+func (m *Model) loadTrips() tea.Cmd {
+	a, ok := m.selected()
+	if !ok || m.fetchTrips == nil {
+		return nil
+	}
+	if _, done := m.trips[a.ID]; done {
+		return nil
+	}
+
+	delete(m.tripErrs, a.ID)
+	m.loading = a.ID
+	fetch := m.fetchTrips
+	id := a.ID
+	return func() tea.Msg {
+		trips, err := fetch(id)
+		return tripsMsg{id: id, trips: trips, err: err}
+	}
+}
+
+func (m Model) selected() (api.Availability, bool) {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.view) {
+		return api.Availability{}, false
+	}
+	return m.results[m.view[cursor]], true
+}
+
+// refresh rebuilds the visible rows after a filter, sort or column change
+func (m *Model) refresh() {
+	query := strings.ToLower(strings.TrimSpace(m.filter.Value()))
+
+	m.view = m.view[:0]
+	for i, a := range m.results {
+		if query == "" || strings.Contains(searchText(a), query) {
+			m.view = append(m.view, i)
+		}
+	}
+
+	key := sortKeys[m.sortKey]
+	sort.SliceStable(m.view, func(i, j int) bool {
+		return less(m.results[m.view[i]], m.results[m.view[j]], key, m.reverse)
+	})
+
+	columns := []table.Column{
+		{Title: "Date", Width: 10},
+		{Title: "From", Width: 4},
+		{Title: "To", Width: 4},
+		{Title: "Source", Width: 20},
+	}
+	for _, cabin := range api.ValidCabins() {
+		if m.cabins[cabin] {
+			columns = append(columns, table.Column{Title: cabin, Width: 10})
+		}
+	}
+
+	rows := make([]table.Row, 0, len(m.view))
+	for _, idx := range m.view {
+		a := m.results[idx]
+		row := table.Row{a.Date, a.Route.OriginAirport, a.Route.DestinationAirport, api.SourceDisplayName(a.Source)}
+		for _, cabin := range api.ValidCabins() {
+			if m.cabins[cabin] {
+				row = append(row, cabinCell(a.Cabin(cabin)))
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	// Rows must be cleared before columns change so they never disagree in
+	// width, which also resets the cursor
+	cursor := m.table.Cursor()
+	m.table.SetRows(nil)
+	m.table.SetColumns(columns)
+	m.table.SetRows(rows)
+	m.table.SetCursor(cursor)
+	m.resize()
+}
+
+func (m *Model) resize() {
+	// Title, filter line, help line, and the bordered detail pane
+	chrome := 3 + detailHeight + 2
+	m.table.SetWidth(m.width)
+	m.table.SetHeight(max(m.height-chrome, 3))
+}
+
+// View implements tea.Model
+func (m Model) View() string {
+	var b strings.Builder
+
+	title := fmt.Sprintf("%d of %d results · sort: %s", len(m.view), len(m.results), sortKeys[m.sortKey])
+	if m.reverse {
+		title += " (desc)"
+	}
+	b.WriteString(headerStyle.Render(title))
+	b.WriteString("\n")
+
+	if m.filtering || m.filter.Value() != "" {
+		b.WriteString(m.filter.View())
+	}
+	b.WriteString("\n")
+
+	b.WriteString(m.table.View())
+	b.WriteString("\n")
+	b.WriteString(detailStyle.Width(max(m.width-2, 20)).Render(m.detailView()))
+	b.WriteString("\n")
+
+	help := "↑/↓ move · / filter · s sort · r reverse · 1-4 toggle Y/W/J/F · q quit"
+	if m.fetchTrips != nil {
+		help = "↑/↓ move · t trips · / filter · s sort · r reverse · 1-4 toggle Y/W/J/F · q quit"
+	}
+	b.WriteString(helpStyle.Render(help))
+
+	return b.String()
+}
+
+func (m Model) detailView() string {
+	a, ok := m.selected()
+	if !ok {
+		return padLines(nil)
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s  %s → %s  %s  (id %s)", a.Date, a.Route.OriginAirport, a.Route.DestinationAirport, api.SourceDisplayName(a.Source), a.ID),
+	}
+	for _, cabin := range api.ValidCabins() {
+		c := a.Cabin(cabin)
+		if !c.Available {
+			continue
+		}
+		direct := "connecting"
+		if c.Direct {
+			direct = "direct"
+		}
+		lines = append(lines, fmt.Sprintf("  %-16s %7s miles  %d seats  %-10s %s",
+			api.CabinDisplayName(cabin), formatMiles(c.Miles), c.RemainingSeats, direct, c.Airlines))
+	}
+
+	switch {
+	case m.loading == a.ID:
+		lines = append(lines, "", "Loading trips...")
+	case m.tripErrs[a.ID] != nil:
+		lines = append(lines, "", errorStyle.Render("Error: "+m.tripErrs[a.ID].Error()))
+	case m.trips[a.ID] != nil:
+		lines = append(lines, "")
+		lines = append(lines, tripLines(m.trips[a.ID])...)
+	}
+
+	return padLines(lines)
+}
+
+func tripLines(trips []api.Trip) []string {
+	if len(trips) == 0 {
+		return []string{"No trips found."}
+	}
+
+	var lines []string
+	for i, t := range trips {
+		lines = append(lines, fmt.Sprintf("Trip %d: %s  %s  %dh%02dm  %d stop(s)  %s miles  %d seats",
+			i+1, t.Cabin, t.FlightNumbers, t.TotalDuration/60, t.TotalDuration%60, t.Stops, formatMiles(t.PartyMiles()), t.RemainingSeats))
+		for _, seg := range t.AvailabilitySegments {
+			lines = append(lines, fmt.Sprintf("    %-7s %s %s → %s %s  %s %s",
+				seg.FlightNumber,
+				seg.OriginAirport, seg.DepartsAt.Format("01-02 15:04"),
+				seg.DestinationAirport, seg.ArrivesAt.Format("01-02 15:04"),
+				seg.AircraftCode, seg.FareClass))
+		}
+	}
+	return lines
+}
+
+// padLines fits lines to exactly detailHeight so the layout doesn't jump
+func padLines(lines []string) string {
+	if len(lines) > detailHeight {
+		more := len(lines) - detailHeight + 1
+		lines = append(lines[:detailHeight-1:detailHeight-1], fmt.Sprintf("... %d more lines", more))
+	}
+	for len(lines) < detailHeight {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func cabinCell(c api.CabinAvailability) string {
+	if !c.Available {
+		return "-"
+	}
+	cell := fmt.Sprintf("%s/%d", formatMiles(c.Miles), c.RemainingSeats)
+	if c.Direct {
+		cell += "*"
+	}
+	return cell
+}
+
+func formatMiles(miles int) string {
+	switch {
+	case miles == 0:
+		return "?"
+	case miles%1000 == 0:
+		return fmt.Sprintf("%dk", miles/1000)
+	default:
+		return fmt.Sprintf("%.1fk", float64(miles)/1000)
+	}
+}
+
+func searchText(a api.Availability) string {
+	parts := []string{a.Date, a.Route.OriginAirport, a.Route.DestinationAirport, a.Source, api.SourceDisplayName(a.Source)}
+	for _, cabin := range api.ValidCabins() {
+		parts = append(parts, a.Cabin(cabin).Airlines)
+	}
+	return strings.ToLower(strings.Join(parts, " "))
+}
+
+func less(a, b api.Availability, key string, reverse bool) bool {
+	// Unavailable cabins sort last in either direction
+	if len(key) == 1 {
+		ca, cb := a.Cabin(key), b.Cabin(key)
+		if ca.Available != cb.Available {
+			return ca.Available
+		}
+	}
+	if reverse {
+		a, b = b, a
+	}
+
+	switch key {
+	case "origin":
+		return a.Route.OriginAirport < b.Route.OriginAirport
+	case "destination":
+		return a.Route.DestinationAirport < b.Route.DestinationAirport
+	case "source":
+		return a.Source < b.Source
+	case "Y", "W", "J", "F":
+		return a.Cabin(key).Miles < b.Cabin(key).Miles
+	default:
+		return a.Date < b.Date
+	}
+}
@@ -0,0 +1,179 @@
+package valuation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/JHill6253/seats-aero-cli/internal/api"
+)
+
+// fallbackCPP is used for programs without a default or configured valuation
+const fallbackCPP = 1.0
+
+// defaults are rough cents-per-point valuations for each program; override
+// them with the valuations section of the config file
+var defaults = map[string]float64{
+	"eurobonus":      1.3,
+	"virginatlantic": 1.4,
+	"aeromexico":     0.9,
+	"american":       1.5,
+	"delta":          1.2,
+	"etihad":         1.3,
+	"united":         1.3,
+	"emirates":       1.2,
+	"aeroplan":       1.5,
+	"alaska":         1.6,
+	"velocity":       1.2,
+	"qantas":         1.2,
+	"connectmiles":   1.2,
+	"azul":           0.9,
+	"smiles":         0.8,
+	"flyingblue":     1.3,
+	"jetblue":        1.3,
+	"qatar":          1.3,
+	"turkish":        1.4,
+	"singapore":      1.4,
+	"ethiopian":      1.1,
+	"saudia":         1.0,
+	"finnair":        1.2,
+	"lufthansa":      1.3,
+}
+
+// usdPerUnit holds approximate exchange rates used to fold taxes into an
+// effective cost in US dollars
+var usdPerUnit = map[string]float64{
+	"USD": 1,
+	"CAD": 0.73,
+	"EUR": 1.08,
+	"GBP": 1.27,
+	"AUD": 0.66,
+	"NZD": 0.61,
+	"JPY": 0.0067,
+	"SGD": 0.74,
+	"CHF": 1.12,
+	"SEK": 0.095,
+	"NOK": 0.093,
+	"DKK": 0.145,
+	"MXN": 0.055,
+	"BRL": 0.18,
+	"AED": 0.27,
+	"QAR": 0.27,
+	"SAR": 0.27,
+	"TRY": 0.03,
+	"HKD": 0.13,
+	"KRW": 0.00073,
+}
+
+// Table maps mileage program sources to cents-per-point valuations. The zero
+// value uses the bundled defaults.
+type Table struct {
+	overrides map[string]float64
+}
+
+// New creates a valuation table with per-source overrides on top of the defaults
+func New(overrides map[string]float64) Table {
+	t := Table{overrides: map[string]float64{}}
+	for source, cpp := range overrides {
+		t.overrides[strings.ToLower(source)] = cpp
+	}
+	return t
+}
+
+// CPP returns the cents-per-point valuation for a source
+func (t Table) CPP(source string) float64 {
+	source = strings.ToLower(source)
+	if cpp, ok := t.overrides[source]; ok {
+		return cpp
+	}
+	if cpp, ok := defaults[source]; ok {
+		return cpp
+	}
+	return fallbackCPP
+}
+
+// Value is the estimated cash-equivalent cost of an award
+type Value struct {
+	Source   string  `json:"source"`
+	Miles    int     `json:"miles"`
+	CPP      float64 `json:"cpp"`
+	Points   float64 `json:"pointsValue"` // value of the miles in USD
+	Taxes    float64 `json:"taxes"`       // in Currency
+	Currency string  `json:"currency,omitempty"`
+	Cost     float64 `json:"cost"` // points value plus taxes in USD
+
+	// TaxesExcluded is set when the currency has no bundled exchange rate,
+	// so Cost leaves the taxes out rather than guessing
+	TaxesExcluded bool `json:"taxesExcluded,omitempty"`
+}
+
+// String formats the effective cost, e.g. "$812", with a trailing "+" when
+// taxes in an unknown currency are left out
+func (v Value) String() string {
+	if v.TaxesExcluded {
+		return fmt.Sprintf("$%.0f+", v.Cost)
+	}
+	return fmt.Sprintf("$%.0f", v.Cost)
+}
+
+// Estimate values an award of miles plus taxes in minor currency units
+// (as reported by the API, e.g. cents, or yen for JPY)
+func (t Table) Estimate(source string, miles, taxes int, currency string) Value {
+	v := Value{
+		Source:   source,
+		Miles:    miles,
+		CPP:      t.CPP(source),
+		Taxes:    api.TaxesAmount(taxes, currency),
+		Currency: strings.ToUpper(currency),
+	}
+	v.Points = float64(miles) * v.CPP / 100
+	v.Cost = v.Points
+	if rate, ok := usdRate(v.Currency); ok {
+		v.Cost += v.Taxes * rate
+	} else {
+		v.TaxesExcluded = v.Taxes > 0
+	}
+	return v
+}
+
+// Trip values a trip from its mileage cost and taxes
+func (t Table) Trip(trip api.Trip) Value {
+	return t.Estimate(trip.Source, trip.PartyMiles(), trip.PartyTaxes(), trip.TaxesCurrency)
+}
+
+// Cabin values one cabin of an availability; ok is false when the cabin is
+// unavailable
+func (t Table) Cabin(a api.Availability, cabin string) (Value, bool) {
+	c := a.Cabin(cabin)
+	if !c.Available || c.Miles == 0 {
+		return Value{}, false
+	}
+	return t.Estimate(a.Source, c.Miles, c.TotalTaxes, a.TaxesCurrency), true
+}
+
+// Best returns the lowest effective cost across the given cabins (all cabins
+// when none are given)
+func (t Table) Best(a api.Availability, cabins ...string) (Value, string, bool) {
+	if len(cabins) == 0 {
+		cabins = api.ValidCabins()
+	}
+
+	var best Value
+	var bestCabin string
+	for _, cabin := range cabins {
+		v, ok := t.Cabin(a, cabin)
+		if ok && (bestCabin == "" || v.Cost < best.Cost) {
+			best, bestCabin = v, cabin
+		}
+	}
+	return best, bestCabin, bestCabin != ""
+}
+
+// usdRate converts a currency to USD, treating an empty currency as USD; ok
+// is false for currencies without a bundled rate
+func usdRate(currency string) (float64, bool) {
+	if currency == "" {
+		return 1, true
+	}
+	rate, ok := usdPerUnit[currency]
+	return rate, ok
+}
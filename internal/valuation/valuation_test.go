@@ -0,0 +1,35 @@
+package valuation
+
+import "testing"
+
+func TestEstimate(t *testing.T) {
+	table := New(map[string]float64{"Aeroplan": 2})
+
+	tests := []struct {
+		name     string
+		source   string
+		miles    int
+		taxes    int
+		currency string
+		cost     float64
+		excluded bool
+		str      string
+	}{
+		{"override", "aeroplan", 70000, 5600, "USD", 1456, false, "$1456"},
+		{"default", "united", 10000, 0, "", 130, false, "$130"},
+		{"empty currency is USD", "unknownprogram", 10000, 1000, "", 110, false, "$110"},
+		{"converted", "united", 10000, 10000, "EUR", 238, false, "$238"},
+		{"zero-decimal currency", "united", 10000, 6000, "JPY", 170.2, false, "$170"},
+		{"zero-decimal lowercase", "united", 10000, 100000, "krw", 203, false, "$203"},
+		{"unknown currency", "united", 10000, 10000, "XYZ", 130, true, "$130+"},
+		{"unknown currency, no taxes", "united", 10000, 0, "XYZ", 130, false, "$130"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := table.Estimate(tt.source, tt.miles, tt.taxes, tt.currency)
+			if v.Cost < tt.cost-0.01 || v.Cost > tt.cost+0.01 || v.TaxesExcluded != tt.excluded || v.String() != tt.str {
+				t.Errorf("Estimate = %+v (%s), want cost %.2f excluded %v (%s)", v, v, tt.cost, tt.excluded, tt.str)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package wallet
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Currency is a transferable points currency and its airline partners
+type Currency struct {
+	ID   string
+	Name string
+	// Partners maps a program source to the program miles received per point
+	Partners map[string]float64
+}
+
+// currencies is the bundled transfer-partner graph, keyed by the program
+// names in api.ValidSources(). Ratios are approximate and change over time;
+// override them with wallet.transfers in the config file.
+var currencies = []Currency{
+	{
+		ID:   "amex",
+		Name: "Amex Membership Rewards",
+		Partners: map[string]float64{
+			"aeroplan":       1,
+			"aeromexico":     1.6,
+			"delta":          1,
+			"emirates":       0.8,
+			"etihad":         1,
+			"flyingblue":     1,
+			"jetblue":        0.8,
+			"qantas":         1,
+			"qatar":          1,
+			"singapore":      1,
+			"virginatlantic": 1,
+		},
+	},
+	{
+		ID:   "chase",
+		Name: "Chase Ultimate Rewards",
+		Partners: map[string]float64{
+			"aeroplan":       1,
+			"emirates":       1,
+			"flyingblue":     1,
+			"jetblue":        1,
+			"singapore":      1,
+			"united":         1,
+			"virginatlantic": 1,
+		},
+	},
+	{
+		ID:   "citi",
+		Name: "Citi ThankYou Points",
+		Partners: map[string]float64{
+			"aeromexico":     1,
+			"emirates":       0.8,
+			"etihad":         1,
+			"flyingblue":     1,
+			"jetblue":        1,
+			"qantas":         1,
+			"qatar":          1,
+			"singapore":      1,
+			"turkish":        1,
+			"virginatlantic": 1,
+		},
+	},
+	{
+		ID:   "capitalone",
+		Name: "Capital One Miles",
+		Partners: map[string]float64{
+			"aeromexico":     1,
+			"aeroplan":       1,
+			"emirates":       0.75,
+			"etihad":         1,
+			"finnair":        1,
+			"flyingblue":     1,
+			"qantas":         1,
+			"singapore":      1,
+			"turkish":        1,
+			"virginatlantic": 1,
+		},
+	},
+	{
+		ID:   "bilt",
+		Name: "Bilt Rewards",
+		Partners: map[string]float64{
+			"aeroplan":       1,
+			"alaska":         1,
+			"american":       1,
+			"emirates":       1,
+			"flyingblue":     1,
+			"turkish":        1,
+			"united":         1,
+			"virginatlantic": 1,
+		},
+	},
+}
+
+// Currencies returns the bundled transferable currencies
+func Currencies() []Currency {
+	out := make([]Currency, len(currencies))
+	copy(out, currencies)
+	return out
+}
+
+// Wallet holds point balances and the transfer graph used to reach programs
+type Wallet struct {
+	balances map[string]int
+	graph    map[string]map[string]float64
+	names    map[string]string
+}
+
+// New creates a wallet from balances keyed by program source or currency ID,
+// with transfer ratio overrides keyed by currency ID then program source
+func New(balances map[string]int, transfers map[string]map[string]float64) Wallet {
+	w := Wallet{
+		balances: map[string]int{},
+		graph:    map[string]map[string]float64{},
+		names:    map[string]string{},
+	}
+	for id, balance := range balances {
+		w.balances[strings.ToLower(id)] = balance
+	}
+	for _, c := range currencies {
+		w.names[c.ID] = c.Name
+		w.graph[c.ID] = map[string]float64{}
+		for source, ratio := range c.Partners {
+			w.graph[c.ID][source] = ratio
+		}
+	}
+	for id, partners := range transfers {
+		id = strings.ToLower(id)
+		if w.graph[id] == nil {
+			w.graph[id] = map[string]float64{}
+		}
+		for source, ratio := range partners {
+			source = strings.ToLower(source)
+			if ratio <= 0 {
+				delete(w.graph[id], source)
+				continue
+			}
+			w.graph[id][source] = ratio
+		}
+	}
+	return w
+}
+
+// IsEmpty reports whether the wallet has no balances
+func (w Wallet) IsEmpty() bool {
+	return len(w.balances) == 0
+}
+
+// Balance returns the points held directly in a program or currency
+func (w Wallet) Balance(id string) int {
+	return w.balances[strings.ToLower(id)]
+}
+
+// CurrencyName returns the display name of a transferable currency
+func (w Wallet) CurrencyName(id string) string {
+	if name, ok := w.names[id]; ok {
+		return name
+	}
+	return id
+}
+
+// Path is one way to get miles into a program
+type Path struct {
+	From    string  `json:"from"`  // currency ID, or the program itself for a direct balance
+	Ratio   float64 `json:"ratio"` // program miles per point; 0 for a direct balance
+	Balance int     `json:"balance"`
+	Miles   int     `json:"miles"` // program miles obtainable from the balance
+}
+
+// Direct reports whether the path is a balance held in the program itself
+func (p Path) Direct() bool {
+	return p.Ratio == 0
+}
+
+// Coverage describes whether the wallet can pay for an award
+type Coverage struct {
+	Source    string `json:"source"`
+	Cost      int    `json:"cost"` // miles needed for all passengers
+	Available int    `json:"available"`
+	Covered   bool   `json:"covered"`
+	Paths     []Path `json:"paths"`
+}
+
+// Via lists where the miles come from: the program balance followed by
+// transfer partners, e.g. "aeroplan, amex 1:1"
+func (c Coverage) Via() string {
+	parts := make([]string, 0, len(c.Paths))
+	for _, p := range c.Paths {
+		if p.Direct() {
+			parts = append(parts, c.Source)
+			continue
+		}
+		parts = append(parts, p.From+" "+formatRatio(p.Ratio))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Paths returns every way to get miles into a program, direct balance first
+// then transfer partners by the miles they yield. Partners are included even
+// with no balance so results can show what the program is bookable via.
+func (w Wallet) Paths(source string) []Path {
+	source = strings.ToLower(source)
+
+	var paths []Path
+	if balance, ok := w.balances[source]; ok {
+		paths = append(paths, Path{From: source, Balance: balance, Miles: balance})
+	}
+
+	var transfers []Path
+	for id, partners := range w.graph {
+		ratio, ok := partners[source]
+		if !ok {
+			continue
+		}
+		balance := w.balances[id]
+		transfers = append(transfers, Path{
+			From:    id,
+			Ratio:   ratio,
+			Balance: balance,
+			Miles:   int(float64(balance) * ratio),
+		})
+	}
+	sort.Slice(transfers, func(i, j int) bool {
+		if transfers[i].Miles != transfers[j].Miles {
+			return transfers[i].Miles > transfers[j].Miles
+		}
+		return transfers[i].From < transfers[j].From
+	})
+
+	return append(paths, transfers...)
+}
+
+// Cover checks whether balances, pooled across the program and its transfer
+// partners, cover an award of miles per passenger
+func (w Wallet) Cover(source string, miles, passengers int) Coverage {
+	if passengers < 1 {
+		passengers = 1
+	}
+
+	c := Coverage{Source: strings.ToLower(source), Cost: miles * passengers}
+	for _, p := range w.Paths(source) {
+		// Only list currencies we hold or the program balance itself
+		if p.Balance == 0 && !p.Direct() {
+			continue
+		}
+		c.Paths = append(c.Paths, p)
+		c.Available += p.Miles
+	}
+	c.Covered = c.Cost > 0 && c.Available >= c.Cost
+	return c
+}
+
+func formatRatio(ratio float64) string {
+	return "1:" + strconv.FormatFloat(ratio, 'f', -1, 64)
+}